@@ -0,0 +1,135 @@
+// Command errcodes generates the catalog of error codes registered through errs.RegisterCode.
+//
+// The codes are registered at initialization, as such errcodes builds and runs a temporary
+// program importing the given packages, then writes the catalog of the registered codes.
+// It is meant to be used with go generate, for example:
+//
+//	//go:generate go run github.com/ardikabs/golib/cmd/errcodes -format markdown -o ERRORS.md ./...
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+var programTemplate = template.Must(template.New("main").Parse(`// Code generated by errcodes. DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ardikabs/golib/pkg/errs"
+{{range .Imports}}
+	_ "{{.}}"{{end}}
+)
+
+func main() {
+	if err := errs.{{.Writer}}(os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`))
+
+func main() {
+	var (
+		format = flag.String("format", "markdown", "catalog format, one of markdown or json")
+		output = flag.String("o", "", "output file, defaults to stdout")
+	)
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: errcodes [-format markdown|json] [-o file] packages...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(*format, *output, flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "errcodes: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(format, output string, patterns []string) error {
+	var writer string
+	switch format {
+	case "markdown", "md":
+		writer = "WriteCodesMarkdown"
+	case "json":
+		writer = "WriteCodesJSON"
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	imports, err := listPackages(patterns)
+	if err != nil {
+		return err
+	}
+
+	// the program must live within the current module to resolve its packages
+	dir, err := os.MkdirTemp(".", ".errcodes-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	var program bytes.Buffer
+	if err := programTemplate.Execute(&program, map[string]interface{}{
+		"Imports": imports,
+		"Writer":  writer,
+	}); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), program.Bytes(), 0o600); err != nil {
+		return err
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command("go", "run", "./"+filepath.Base(dir))
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err := os.Stdout.Write(stdout.Bytes())
+		return err
+	}
+
+	return os.WriteFile(output, stdout.Bytes(), 0o644)
+}
+
+// listPackages returns the import path of the non-main packages matching given patterns
+func listPackages(patterns []string) ([]string, error) {
+	args := append([]string{"list", "-f", "{{if ne .Name \"main\"}}{{.ImportPath}}{{end}}"}, patterns...)
+
+	out, err := exec.Command("go", args...).Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("go list: %s", ee.Stderr)
+		}
+		return nil, err
+	}
+
+	var imports []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			imports = append(imports, line)
+		}
+	}
+
+	return imports, nil
+}
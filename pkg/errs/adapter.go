@@ -0,0 +1,34 @@
+package errs
+
+import (
+	"net/http"
+
+	"github.com/rs/zerolog"
+)
+
+// HandlerFunc is an HTTP handler returning an error, it implements http.Handler
+// by handling the returned error, if any, with the default HTTPHandler.
+// The logger is taken from the request context, see zerolog.Ctx.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP calls fn(w, r), handling its returned error
+func (fn HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defaultHandler.serve(fn, w, r)
+}
+
+// HandlerFunc returns an http.Handler calling fn and handling its returned error with h,
+// the logger is taken from the request context, see zerolog.Ctx.
+func (h *HTTPHandler) HandlerFunc(fn HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.serve(fn, w, r)
+	})
+}
+
+func (h *HTTPHandler) serve(fn HandlerFunc, w http.ResponseWriter, r *http.Request) {
+	if err := fn(w, r); err != nil {
+		h.Handle(w, r, *zerolog.Ctx(r.Context()), err)
+	}
+}
+
+// defaultHandler is the HTTPHandler used by HandlerFunc
+var defaultHandler = NewHTTPHandler()
@@ -0,0 +1,64 @@
+package errs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerFunc(t *testing.T) {
+	l := zerolog.New(os.Stdout).Level(zerolog.DebugLevel)
+
+	tests := []struct {
+		name     string
+		fn       errs.HandlerFunc
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "no error",
+			fn: func(w http.ResponseWriter, r *http.Request) error {
+				w.WriteHeader(http.StatusCreated)
+				return nil
+			},
+			wantCode: http.StatusCreated,
+		},
+		{
+			name: "returned error",
+			fn: func(w http.ResponseWriter, r *http.Request) error {
+				return errs.E(errs.NotExist, errs.Code("product_not_exist"), "not exist")
+			},
+			wantCode: http.StatusNotFound,
+			wantBody: `{"error":{"kind":"resource_does_not_exist","code":"product_not_exist","message":"not exist"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			w := httptest.NewRecorder()
+			tt.fn.ServeHTTP(w, r.WithContext(l.WithContext(r.Context())))
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+
+	t.Run("custom handler", func(t *testing.T) {
+		h := errs.NewHTTPHandler(errs.WithRenderer(errs.TextRenderer{}))
+		fn := h.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return errs.E(errs.NotExist, errs.Code("product_not_exist"), "not exist")
+		})
+
+		w := httptest.NewRecorder()
+		fn.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, errs.MIMETextPlain, w.Header().Get("Content-Type"))
+	})
+}
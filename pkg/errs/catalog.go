@@ -0,0 +1,190 @@
+package errs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog holds the localized message templates of error codes, per locale.
+//
+// A template may refer to the error parameter as {param}, and to the error Fields
+// allowed through AllowFields by their key, such as {entity_id}. As Fields are meant for
+// diagnostics, only the allowed ones reach the client, after DefaultRedactor masks
+// the sensitive ones. Unknown placeholders are kept as is.
+//
+// A Catalog is meant to be populated once, then only read, concurrently.
+type Catalog struct {
+	fallback string
+	messages map[string]map[Code]string
+	fields   map[string]bool
+}
+
+// NewCatalog returns an empty Catalog, which falls back to the given locale
+// when none of the requested locales is available.
+func NewCatalog(fallback string) *Catalog {
+	return &Catalog{
+		fallback: normalizeLocale(fallback),
+		messages: make(map[string]map[Code]string),
+		fields:   make(map[string]bool),
+	}
+}
+
+// AllowFields allows the error Fields of given keys to be interpolated into the messages
+func (c *Catalog) AllowFields(keys ...string) {
+	for _, k := range keys {
+		c.fields[k] = true
+	}
+}
+
+// Add adds the message templates of the given locale, replacing the existing ones
+// of the same code.
+func (c *Catalog) Add(locale string, messages map[Code]string) {
+	locale = normalizeLocale(locale)
+	if c.messages[locale] == nil {
+		c.messages[locale] = make(map[Code]string, len(messages))
+	}
+
+	for code, tmpl := range messages {
+		c.messages[locale][code] = tmpl
+	}
+}
+
+// LoadJSON adds the message templates of the given locale from a JSON object of code to template
+func (c *Catalog) LoadJSON(locale string, r io.Reader) error {
+	var messages map[Code]string
+	if err := json.NewDecoder(r).Decode(&messages); err != nil {
+		return fmt.Errorf("errs: unable to load %s catalog: %w", locale, err)
+	}
+
+	c.Add(locale, messages)
+	return nil
+}
+
+// LoadYAML adds the message templates of the given locale from a YAML mapping of code to template
+func (c *Catalog) LoadYAML(locale string, r io.Reader) error {
+	var messages map[Code]string
+	if err := yaml.NewDecoder(r).Decode(&messages); err != nil {
+		return fmt.Errorf("errs: unable to load %s catalog: %w", locale, err)
+	}
+
+	c.Add(locale, messages)
+	return nil
+}
+
+// LoadFS adds the message templates of every locale file within the given directory of fsys,
+// such as an embed.FS. The file is named after its locale, with a .json, .yaml or .yml extension,
+// for example "en.json" or "id-ID.yaml". Other files are ignored.
+func (c *Catalog) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		ext := path.Ext(entry.Name())
+		locale := strings.TrimSuffix(entry.Name(), ext)
+
+		var load func(string, io.Reader) error
+		switch ext {
+		case ".json":
+			load = c.LoadJSON
+		case ".yaml", ".yml":
+			load = c.LoadYAML
+		default:
+			continue
+		}
+
+		f, err := fsys.Open(path.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+
+		err = load(locale, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Match returns the best available locale for the given Accept-Language header,
+// trying the exact tag first, then its base language, then the fallback locale.
+func (c *Catalog) Match(acceptLanguage string) string {
+	for _, spec := range parseAccept(acceptLanguage) {
+		if spec.value == "*" {
+			break
+		}
+
+		if _, ok := c.messages[spec.value]; ok {
+			return spec.value
+		}
+
+		if base, _, ok := strings.Cut(spec.value, "-"); ok {
+			if _, ok := c.messages[base]; ok {
+				return base
+			}
+		}
+	}
+
+	return c.fallback
+}
+
+// Message returns the message of the error code in the given locale,
+// interpolated with the error parameter and allowed fields.
+// It reports false if the code has no message in the locale nor in the fallback locale.
+func (c *Catalog) Message(locale string, e *Error) (string, bool) {
+	msg, _, ok := c.lookup(locale, e)
+	return msg, ok
+}
+
+// lookup is the same as Message, in addition it returns the locale of the message
+func (c *Catalog) lookup(locale string, e *Error) (string, string, bool) {
+	locale = normalizeLocale(locale)
+
+	tmpl, ok := c.messages[locale][e.Code]
+	if !ok {
+		locale = c.fallback
+		tmpl, ok = c.messages[locale][e.Code]
+	}
+
+	if !ok {
+		return "", "", false
+	}
+
+	return c.interpolate(tmpl, e), locale, true
+}
+
+var placeholderRegex = regexp.MustCompile(`\{(\w+)\}`)
+
+func (c *Catalog) interpolate(tmpl string, e *Error) string {
+	fields := DefaultRedactor.Fields(e.Fields)
+	return placeholderRegex.ReplaceAllStringFunc(tmpl, func(m string) string {
+		key := m[1 : len(m)-1]
+		if key == "param" {
+			return string(e.Param)
+		}
+
+		if v, ok := fields[key]; ok && c.fields[key] {
+			return fmt.Sprint(v)
+		}
+
+		return m
+	})
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
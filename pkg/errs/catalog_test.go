@@ -0,0 +1,117 @@
+package errs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCatalog(t *testing.T) *errs.Catalog {
+	fsys := fstest.MapFS{
+		"i18n/en.json":    {Data: []byte(`{"product_not_exist": "product {product_id} does not exist", "bad_format": "{param} has a bad format"}`)},
+		"i18n/id.yaml":    {Data: []byte("product_not_exist: produk {product_id} tidak ditemukan\nbad_format: format {param} tidak valid\n")},
+		"i18n/README.md":  {Data: []byte("ignored")},
+		"i18n/nested/x.y": {Data: []byte("ignored")},
+	}
+
+	c := errs.NewCatalog("en")
+	require.NoError(t, c.LoadFS(fsys, "i18n"))
+	c.AllowFields("product_id")
+	return c
+}
+
+func TestCatalog(t *testing.T) {
+	c := newTestCatalog(t)
+
+	t.Run("match", func(t *testing.T) {
+		assert.Equal(t, "id", c.Match("id"))
+		assert.Equal(t, "id", c.Match("id-ID,id;q=0.9,en;q=0.8"))
+		assert.Equal(t, "en", c.Match("fr-FR, en;q=0.5, id;q=0.1"))
+		assert.Equal(t, "en", c.Match("fr"))
+		assert.Equal(t, "en", c.Match(""))
+	})
+
+	t.Run("message", func(t *testing.T) {
+		err := errs.E(errs.NotExist, errs.Code("product_not_exist"), errs.Field("product_id", 14), "not exist").(*errs.Error)
+
+		msg, ok := c.Message("id", err)
+		assert.True(t, ok)
+		assert.Equal(t, "produk 14 tidak ditemukan", msg)
+
+		msg, ok = c.Message("fr", err)
+		assert.True(t, ok)
+		assert.Equal(t, "product 14 does not exist", msg)
+
+		_, ok = c.Message("en", errs.E(errs.Code("unknown_code")).(*errs.Error))
+		assert.False(t, ok)
+	})
+
+	t.Run("unknown placeholder is kept", func(t *testing.T) {
+		c := errs.NewCatalog("en")
+		c.Add("en", map[errs.Code]string{"x": "{missing} {param}"})
+
+		msg, _ := c.Message("en", errs.E(errs.Code("x"), errs.Parameter("key")).(*errs.Error))
+		assert.Equal(t, "{missing} key", msg)
+	})
+
+	t.Run("only allowed fields are interpolated", func(t *testing.T) {
+		c := errs.NewCatalog("en")
+		c.Add("en", map[errs.Code]string{"x": "{entity_id} {tenant_id} {token}"})
+		c.AllowFields("entity_id", "token")
+
+		err := errs.E(errs.Code("x"), errs.Field("entity_id", 14), errs.Field("tenant_id", "acme"), errs.Field("token", errs.Sensitive("secret")))
+		msg, _ := c.Message("en", err.(*errs.Error))
+		assert.Equal(t, "14 {tenant_id} [REDACTED]", msg)
+	})
+
+	t.Run("allowed fields are redacted", func(t *testing.T) {
+		errs.DefaultRedactor = &errs.Redactor{SensitiveFields: []string{"email"}}
+		t.Cleanup(func() { errs.DefaultRedactor = nil })
+
+		c := errs.NewCatalog("en")
+		c.Add("en", map[errs.Code]string{"x": "{email} is taken"})
+		c.AllowFields("email")
+
+		msg, _ := c.Message("en", errs.E(errs.Code("x"), errs.Field("email", "john@doe.com")).(*errs.Error))
+		assert.Equal(t, "[REDACTED] is taken", msg)
+	})
+
+	t.Run("bad file", func(t *testing.T) {
+		assert.Error(t, errs.NewCatalog("en").LoadJSON("en", strings.NewReader(`[]`)))
+		assert.Error(t, errs.NewCatalog("en").LoadYAML("en", strings.NewReader(`- a`)))
+	})
+}
+
+func TestHTTPHandler_Catalog(t *testing.T) {
+	l := zerolog.New(os.Stdout).Level(zerolog.DebugLevel)
+	h := errs.NewHTTPHandler(errs.WithCatalog(newTestCatalog(t)))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Language", "id-ID,id;q=0.9")
+
+	w := httptest.NewRecorder()
+	h.Handle(w, r, l, errs.E(errs.NotExist, errs.Code("product_not_exist"), errs.Field("product_id", 14), "product=14 not found in db"))
+	assert.Equal(t, `{"error":{"kind":"resource_does_not_exist","code":"product_not_exist","message":"produk 14 tidak ditemukan"}}`, w.Body.String())
+	assert.Equal(t, "id", w.Header().Get("Content-Language"))
+	assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+
+	w = httptest.NewRecorder()
+	h.Handle(w, r, l, errs.E(errs.Validation, errs.ValidationErrors{
+		errs.E(errs.Parameter("email"), errs.Code("bad_format"), "bad format"),
+		errs.E(errs.Parameter("name"), "name is required"),
+	}))
+	assert.Equal(t, `{"errors":[{"code":"bad_format","param":"email","pointer":"/email","message":"format email tidak valid"},{"param":"name","pointer":"/name","message":"name is required"}]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	h.Handle(w, nil, l, errs.E(errs.NotExist, errs.Code("product_not_exist"), errs.Field("product_id", 14), "not found"))
+	assert.Contains(t, w.Body.String(), "product 14 does not exist")
+	assert.Equal(t, "en", w.Header().Get("Content-Language"))
+}
@@ -0,0 +1,195 @@
+package errs
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FromHTTPResponse translates the error response of another service, rendered by HTTPHandler,
+// back into an error. It returns nil if the response status code is not an error, that is below 400.
+//
+// The body is decoded as HTTPErrResponse, or as ProblemDetails for the application/problem+json
+// media type, rebuilding the *Error with its Kind, Code, Param and message. The validation
+// errors are rebuilt as ValidationErrors, and the errors of distinct kinds as MultiError.
+// The Kind missing from the body, or not registered, derives from the status code,
+// see KindFromHTTPStatus. The body is left readable for the caller.
+func FromHTTPResponse(resp *http.Response) error {
+	if resp == nil || resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	var body []byte
+	if resp.Body != nil {
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return E(KindFromHTTPStatus(resp.StatusCode), err)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(b))
+		body = b
+	}
+
+	kind := KindFromHTTPStatus(resp.StatusCode)
+
+	var args []interface{}
+
+	if realm := authRealm(resp.Header.Get("WWW-Authenticate")); realm != "" {
+		args = append(args, Realm(realm))
+	}
+
+	if s := resp.Header.Get("Retry-After"); s != "" {
+		if secs, err := strconv.Atoi(s); err == nil {
+			args = append(args, RetryAfter(time.Duration(secs)*time.Second))
+		}
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case MIMEApplicationProblemJSON:
+		var problem ProblemDetails
+		if err := json.Unmarshal(body, &problem); err == nil {
+			return fromProblem(append(args, kind), problem)
+		}
+	case MIMEApplicationJSON:
+		var r HTTPErrResponse
+		if err := json.Unmarshal(body, &r); err == nil && (r.Error != nil || len(r.Errors) > 0) {
+			return fromHTTPErrResponse(args, kind, r)
+		}
+	}
+
+	msg := strings.TrimSpace(string(body))
+	if msg == "" {
+		msg = http.StatusText(resp.StatusCode)
+	}
+
+	return E(append(args, kind, msg)...)
+}
+
+// KindFromHTTPStatus returns the first registered Kind responded with the given HTTP status code,
+// such as NotExist for http.StatusNotFound, or Other if none.
+func KindFromHTTPStatus(status int) Kind {
+	kindRegistry.RLock()
+	defer kindRegistry.RUnlock()
+
+	kind, found := Other, false
+	for k, info := range kindRegistry.kinds {
+		if info.HTTPStatus == status && (!found || k < kind) {
+			kind, found = k, true
+		}
+	}
+
+	return kind
+}
+
+func fromHTTPErrResponse(args []interface{}, kind Kind, r HTTPErrResponse) error {
+	if r.Error != nil {
+		return fromServiceError(append(args, kind), *r.Error)
+	}
+
+	// The errors of distinct kinds are rendered with their Kind, while the validation errors are not.
+	var kinded bool
+	for _, se := range r.Errors {
+		if se.Kind != "" {
+			kinded = true
+			break
+		}
+	}
+
+	if kinded {
+		// The Kind is left to the most severe of the errors, see MultiError.Kind.
+		var m MultiError
+		for _, se := range r.Errors {
+			m.Append(fromServiceError(nil, se))
+		}
+		return E(append(args, m)...)
+	}
+
+	verr := make(ValidationErrors, 0, len(r.Errors))
+	for _, se := range r.Errors {
+		verr = append(verr, serviceErrorE(nil, se))
+	}
+
+	return E(append(args, Validation, verr)...)
+}
+
+func fromServiceError(args []interface{}, se ServiceError) error {
+	if k, err := ParseKind(se.Kind); err == nil {
+		args = append(args, k)
+	}
+
+	return serviceErrorE(args, se)
+}
+
+func serviceErrorE(args []interface{}, se ServiceError) error {
+	if se.Code != "" {
+		args = append(args, Code(se.Code))
+	}
+	if se.Param != "" {
+		args = append(args, Parameter(se.Param))
+	} else if se.Pointer != "" {
+		args = append(args, ParsePointer(se.Pointer))
+	}
+	if se.Message != "" {
+		args = append(args, Message(se.Message), se.Message)
+	}
+	if len(args) == 0 {
+		args = append(args, ErrUndefined)
+	}
+
+	return E(args...)
+}
+
+func fromProblem(args []interface{}, problem ProblemDetails) error {
+	if k, ok := problemKind(problem.Type); ok {
+		args = append(args, k)
+	}
+
+	if len(problem.Errors) == 0 {
+		return serviceErrorE(args, ServiceError{Code: problem.Code, Message: problem.Detail})
+	}
+
+	verr := make(ValidationErrors, 0, len(problem.Errors))
+	for _, pe := range problem.Errors {
+		verr = append(verr, serviceErrorE(nil, ServiceError{
+			Code:    pe.Code,
+			Param:   string(ParsePointer(pe.Pointer)),
+			Message: pe.Detail,
+		}))
+	}
+
+	return E(append(args, Validation, verr)...)
+}
+
+// problemKind returns the Kind which name ends the problem type URI, the longest one wins
+func problemKind(typ string) (Kind, bool) {
+	kindRegistry.RLock()
+	defer kindRegistry.RUnlock()
+
+	var (
+		kind Kind
+		name string
+	)
+	for n, k := range kindRegistry.names {
+		if strings.HasSuffix(typ, n) && len(n) > len(name) {
+			kind, name = k, n
+		}
+	}
+
+	return kind, name != ""
+}
+
+// authRealm returns the realm of the WWW-Authenticate header, if any
+func authRealm(header string) string {
+	_, rest, ok := strings.Cut(header, `realm="`)
+	if !ok {
+		return ""
+	}
+
+	realm, _, _ := strings.Cut(rest, `"`)
+	return realm
+}
@@ -0,0 +1,131 @@
+package errs_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromHTTPResponse(t *testing.T) {
+	l := zerolog.New(os.Stdout).Level(zerolog.DebugLevel)
+
+	respond := func(err error, opts ...errs.HandlerOption) *http.Response {
+		w := httptest.NewRecorder()
+		errs.NewHTTPHandler(opts...).Handle(w, nil, l, err)
+		return w.Result()
+	}
+
+	t.Run("common error", func(t *testing.T) {
+		err := errs.FromHTTPResponse(respond(errs.E(errs.NotExist, errs.Code("product_not_exist"), errs.Parameter("id"), "product not exist")))
+
+		want := errs.E(errs.NotExist, errs.Code("product_not_exist"), errs.Parameter("id"), errs.Message("product not exist"), "product not exist")
+		assert.True(t, errs.Match(want, err), err)
+	})
+
+	t.Run("hidden kind", func(t *testing.T) {
+		err := errs.FromHTTPResponse(respond(errs.E(errs.Database, "duplicate key")))
+
+		assert.True(t, errs.KindIs(errs.Database, err))
+		assert.Equal(t, "internal server error", err.Error())
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		for name, opts := range map[string][]errs.HandlerOption{
+			"json":    nil,
+			"problem": {errs.WithRenderer(errs.ProblemRenderer{})},
+		} {
+			t.Run(name, func(t *testing.T) {
+				err := errs.FromHTTPResponse(respond(errs.E(errs.Validation, errs.ValidationErrors{
+					errs.E(errs.Parameter("email"), errs.Code("required"), "email is required"),
+					errs.E(errs.Parameter("address/city"), "city is required"),
+					errs.E(errs.Path("items", 2, "sku"), "sku is required"),
+				}), opts...))
+
+				require.True(t, errs.KindIs(errs.Validation, err), err)
+				verr, ok := err.(*errs.Error).Err.(errs.ValidationErrors)
+				require.True(t, ok)
+				require.Len(t, verr, 3)
+				assert.True(t, errs.Match(errs.E(errs.Parameter("email"), errs.Code("required"), "email is required"), verr[0]), verr[0])
+				assert.True(t, errs.Match(errs.E(errs.Parameter("address/city"), "city is required"), verr[1]), verr[1])
+				assert.True(t, errs.Match(errs.E(errs.Parameter("items[2].sku"), "sku is required"), verr[2]), verr[2])
+			})
+		}
+	})
+
+	t.Run("problem details", func(t *testing.T) {
+		err := errs.FromHTTPResponse(respond(errs.E(errs.Unavailable, errs.Code("upstream_down"), errs.Message("upstream is down"), "dial tcp"),
+			errs.WithRenderer(errs.ProblemRenderer{TypeBaseURI: "https://example.com/problems/"})))
+
+		assert.True(t, errs.Match(errs.E(errs.Unavailable, errs.Code("upstream_down"), errs.Message("upstream is down"), "upstream is down"), err), err)
+	})
+
+	t.Run("multiple errors", func(t *testing.T) {
+		err := errs.FromHTTPResponse(respond(errs.Join(errs.E(errs.NotExist, "a"), errs.E(errs.Conflict, "b"))))
+
+		assert.True(t, errs.KindIs(errs.Conflict, err))
+		m, ok := err.(*errs.Error).Err.(errs.MultiError)
+		require.True(t, ok)
+		require.Len(t, m, 2)
+		assert.True(t, errs.KindIs(errs.NotExist, m[0]))
+		assert.True(t, errs.KindIs(errs.Conflict, m[1]))
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		err := errs.FromHTTPResponse(respond(errs.E(errs.Unauthenticated, errs.Realm("admin"), "token expired")))
+
+		require.True(t, errs.KindIs(errs.Unauthenticated, err))
+		assert.Equal(t, errs.Realm("admin"), err.(*errs.Error).Realm)
+	})
+
+	t.Run("retry after", func(t *testing.T) {
+		err := errs.FromHTTPResponse(respond(errs.E(errs.RateLimited, errs.RetryAfter(1500*time.Millisecond), "slow down")))
+
+		d, ok := errs.RetryAfterOf(err)
+		assert.True(t, ok)
+		assert.Equal(t, 2*time.Second, d)
+	})
+
+	t.Run("pointer only", func(t *testing.T) {
+		err := errs.FromHTTPResponse(&http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"error":{"kind":"resource_does_not_exist","pointer":"/items/2/sku","message":"sku not exist"}}`)),
+		})
+
+		assert.Equal(t, errs.Parameter("items[2].sku"), err.(*errs.Error).Param)
+	})
+
+	t.Run("foreign body", func(t *testing.T) {
+		resp := &http.Response{
+			StatusCode: http.StatusBadGateway,
+			Header:     http.Header{"Content-Type": {"text/html"}},
+			Body:       io.NopCloser(strings.NewReader("<h1>bad gateway</h1>")),
+		}
+		err := errs.FromHTTPResponse(resp)
+
+		assert.Equal(t, "<h1>bad gateway</h1>", err.Error())
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "<h1>bad gateway</h1>", string(body), "body is left readable")
+	})
+
+	t.Run("not an error", func(t *testing.T) {
+		assert.NoError(t, errs.FromHTTPResponse(&http.Response{StatusCode: http.StatusOK}))
+		assert.NoError(t, errs.FromHTTPResponse(nil))
+	})
+}
+
+func TestKindFromHTTPStatus(t *testing.T) {
+	assert.Equal(t, errs.NotExist, errs.KindFromHTTPStatus(http.StatusNotFound))
+	assert.Equal(t, errs.Other, errs.KindFromHTTPStatus(http.StatusInternalServerError))
+	assert.Equal(t, errs.Unauthenticated, errs.KindFromHTTPStatus(http.StatusUnauthorized))
+	assert.Equal(t, errs.Other, errs.KindFromHTTPStatus(http.StatusTeapot))
+}
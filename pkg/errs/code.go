@@ -0,0 +1,150 @@
+package errs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CodeInfo declares an error Code, along with its defaults and documentation.
+type CodeInfo struct {
+	// Code is the registered error code
+	Code Code
+
+	// Kind is the Kind set by E for an error having this code, when no Kind is given
+	Kind Kind
+
+	// Message is the Message set by E for an error having this code, when no Message is given
+	Message Message
+
+	// DocsURL is the location of the documentation of this code, rendered along with the error
+	DocsURL string
+
+	// Description documents the code in the generated catalog
+	Description string
+}
+
+var codeRegistry = struct {
+	sync.RWMutex
+	codes map[Code]CodeInfo
+}{codes: make(map[Code]CodeInfo)}
+
+// RegisterCode registers the code declared by given info, and returns the code, as such
+// it is meant to be called once, at initialization:
+//
+//	var CodeProductNotExist = errs.RegisterCode(errs.CodeInfo{
+//		Code:    "product_not_exist",
+//		Kind:    errs.NotExist,
+//		Message: "product does not exist",
+//	})
+//
+// RegisterCode panics if the code is empty or already registered.
+func RegisterCode(info CodeInfo) Code {
+	if info.Code == "" {
+		panic("errs.RegisterCode: empty code")
+	}
+
+	codeRegistry.Lock()
+	defer codeRegistry.Unlock()
+
+	if _, exist := codeRegistry.codes[info.Code]; exist {
+		panic(fmt.Sprintf("errs.RegisterCode: duplicate code %q", info.Code))
+	}
+
+	codeRegistry.codes[info.Code] = info
+	return info.Code
+}
+
+// LookupCode returns the info of a registered code
+func LookupCode(code Code) (CodeInfo, bool) {
+	codeRegistry.RLock()
+	defer codeRegistry.RUnlock()
+
+	info, ok := codeRegistry.codes[code]
+	return info, ok
+}
+
+// Codes returns the info of every registered code, ordered by code
+func Codes() []CodeInfo {
+	codeRegistry.RLock()
+	defer codeRegistry.RUnlock()
+
+	infos := make([]CodeInfo, 0, len(codeRegistry.codes))
+	for _, info := range codeRegistry.codes {
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Code < infos[j].Code
+	})
+
+	return infos
+}
+
+// codeDocsURL returns the documentation location of a registered code, if any
+func codeDocsURL(code Code) string {
+	if code == "" {
+		return ""
+	}
+
+	info, _ := LookupCode(code)
+	return info.DocsURL
+}
+
+// WriteCodesJSON writes the catalog of registered codes as a JSON array
+func WriteCodesJSON(w io.Writer) error {
+	type entry struct {
+		Code        Code    `json:"code"`
+		Kind        string  `json:"kind"`
+		Status      int     `json:"status"`
+		Message     Message `json:"message,omitempty"`
+		DocsURL     string  `json:"docs_url,omitempty"`
+		Description string  `json:"description,omitempty"`
+	}
+
+	entries := []entry{}
+	for _, info := range Codes() {
+		entries = append(entries, entry{
+			Code:        info.Code,
+			Kind:        info.Kind.String(),
+			Status:      HTTPStatusCodeFromKind(info.Kind),
+			Message:     info.Message,
+			DocsURL:     info.DocsURL,
+			Description: info.Description,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+// WriteCodesMarkdown writes the catalog of registered codes as a Markdown table
+func WriteCodesMarkdown(w io.Writer) error {
+	escape := strings.NewReplacer("|", `\|`, "\n", " ")
+
+	var b strings.Builder
+	b.WriteString("| Code | Kind | HTTP Status | Message | Description |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+
+	for _, info := range Codes() {
+		code := fmt.Sprintf("`%s`", info.Code)
+		if info.DocsURL != "" {
+			code = fmt.Sprintf("[%s](%s)", code, info.DocsURL)
+		}
+
+		fmt.Fprintf(&b, "| %s | %s | %d | %s | %s |\n",
+			code,
+			info.Kind.String(),
+			HTTPStatusCodeFromKind(info.Kind),
+			escape.Replace(string(info.Message)),
+			escape.Replace(info.Description),
+		)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,73 @@
+package errs_test
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var codeTestNotExist = errs.RegisterCode(errs.CodeInfo{
+	Code:        "test_product_not_exist",
+	Kind:        errs.NotExist,
+	Message:     "product does not exist",
+	DocsURL:     "https://example.com/errors/test_product_not_exist",
+	Description: "The requested product | item does not exist",
+})
+
+func TestRegisterCode(t *testing.T) {
+	t.Run("defaults are filled", func(t *testing.T) {
+		e := errs.E(codeTestNotExist, "product=14 not found").(*errs.Error)
+		assert.Equal(t, errs.NotExist, e.Kind)
+		assert.Equal(t, errs.Message("product does not exist"), e.Message)
+	})
+
+	t.Run("given values win", func(t *testing.T) {
+		e := errs.E(codeTestNotExist, errs.Invalid, errs.Message("gone"), "product=14 not found").(*errs.Error)
+		assert.Equal(t, errs.Invalid, e.Kind)
+		assert.Equal(t, errs.Message("gone"), e.Message)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		assert.Panics(t, func() {
+			errs.RegisterCode(errs.CodeInfo{Code: codeTestNotExist})
+		})
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Panics(t, func() {
+			errs.RegisterCode(errs.CodeInfo{})
+		})
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		info, ok := errs.LookupCode(codeTestNotExist)
+		assert.True(t, ok)
+		assert.Equal(t, errs.NotExist, info.Kind)
+
+		_, ok = errs.LookupCode("test_unregistered")
+		assert.False(t, ok)
+	})
+
+	t.Run("docs url is rendered", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		errs.HTTPErrorHandler(w, zerolog.New(os.Stdout), errs.E(codeTestNotExist, "product=14 not found"))
+		assert.Equal(t, `{"error":{"kind":"resource_does_not_exist","code":"test_product_not_exist","message":"product does not exist","docs_url":"https://example.com/errors/test_product_not_exist"}}`, w.Body.String())
+	})
+}
+
+func TestWriteCodes(t *testing.T) {
+	var md bytes.Buffer
+	require.NoError(t, errs.WriteCodesMarkdown(&md))
+	assert.Contains(t, md.String(), "| [`test_product_not_exist`](https://example.com/errors/test_product_not_exist) | resource_does_not_exist | 404 | product does not exist | The requested product \\| item does not exist |\n")
+
+	var js bytes.Buffer
+	require.NoError(t, errs.WriteCodesJSON(&js))
+	assert.Contains(t, js.String(), `"code": "test_product_not_exist"`)
+	assert.Contains(t, js.String(), `"status": 404`)
+}
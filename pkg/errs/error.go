@@ -1,17 +1,19 @@
 package errs
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
-
-	errs "errors"
-
-	"github.com/pkg/errors"
+	"strings"
 )
 
 // UserName is a string representing a user
 type UserName string
 
+// Op describes an operation, usually as the package and method,
+// such as "user.Create".
+type Op string
+
 // Kind defines the kind of error this is
 type Kind uint8
 
@@ -21,6 +23,20 @@ type Code string
 // Parameter represents the parameter related to the error.
 type Parameter string
 
+// Message is a safe, user-facing message of the error. Unlike the message
+// of the underlying error, it is what gets rendered to the client.
+type Message string
+
+// Fields is an arbitrary structured metadata attached to the error,
+// such as request, entity or tenant identifier. It is logged along with
+// the error, but never rendered to the client.
+type Fields map[string]interface{}
+
+// Field returns Fields with a single key-value pair
+func Field(key string, value interface{}) Fields {
+	return Fields{key: value}
+}
+
 // Realm is a description of a protected area, used in the WWW-Authenticate header.
 // Realm should be set when error Kind is Unauthenticated. If left unset, Realm
 // will be set to the default set by the "restricted" method
@@ -33,6 +49,10 @@ type Error struct {
 	// User is the username of the user attempting the operation.
 	User UserName
 
+	// Op is the operation being performed, usually the name of the method
+	// being invoked (user.Create, db.Insert, etc.).
+	Op Op
+
 	// Kind is the class of error, such as permission failure,
 	// or "Other" if its class is unknown or irrelevant.
 	Kind Kind
@@ -43,47 +63,95 @@ type Error struct {
 	// Param represents the parameter related to the error.
 	Param Parameter
 
+	// Message is the user-facing message of the error.
+	Message Message
+
 	// Realm is a description of a protected area, used in the WWW-Authenticate header.
 	Realm Realm
 
+	// Fields is the structured metadata attached to the error.
+	Fields Fields
+
+	// Retry tells whether the failed operation is worth retrying,
+	// if left unset it derives from the Kind, see IsRetryable.
+	Retry Retry
+
+	// RetryAfter hints how long to wait before retrying the failed operation.
+	RetryAfter RetryAfter
+
 	// The underlying error that triggered this one, if any.
 	Err error
-}
 
-// Is is method to satisfy errors.Is interface
-func (e *Error) Is(target error) bool {
-	return errs.Is(e.Err, target)
+	// stack is the call stack captured by E, if any.
+	stack stack
 }
 
-// As is method to satisfy errors.As interface
-func (w *Error) As(target interface{}) bool {
-	return errs.As(w.Err, target)
+// Is reports whether the error is of the Kind of the target sentinel, see KindError.
+// The underlying errors are matched by errors.Is through Unwrap.
+func (e *Error) Is(target error) bool {
+	k, ok := target.(kindError)
+	return ok && e.Kind == Kind(k)
 }
 
 func (e *Error) Cause() error {
 	return e.Err
 }
 
-func (e Error) Unwrap() error {
-	return errs.Unwrap(e.Err)
+// Unwrap returns the underlying error, so that errors.Is and errors.As
+// walk the whole chain of errors, starting from this one.
+func (e *Error) Unwrap() error {
+	return e.Err
 }
 
 func (e *Error) Error() string {
-	return e.Err.Error()
+	if e.Op == "" {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", e.Op, e.Err.Error())
+}
+
+// Detail returns the message of the error without the trail of operations,
+// that is the message of the innermost underlying error that is not an *Error,
+// looking through the errors wrapping an *Error, such as with fmt.Errorf and %w.
+// Unlike Error, which is meant for logs, it is what gets shown to the client
+// when the error has no Message.
+func (e *Error) Detail() string {
+	return detail(e)
+}
+
+func detail(err error) string {
+	switch err := err.(type) {
+	case nil:
+		return ""
+	case *Error:
+		return detail(err.Err)
+	case MultiError:
+		msgs := make([]string, 0, len(err))
+		for _, e := range err {
+			msgs = append(msgs, detail(e))
+		}
+		return strings.Join(msgs, "; ")
+	default:
+		var e *Error
+		if errors.As(err, &e) {
+			return detail(e)
+		}
+		return err.Error()
+	}
 }
 
+// Format formats the error message, followed by the stack trace
+// of the error chain when the verb is %+v
 func (e *Error) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
-			if st, ok := e.Err.(interface {
-				StackTrace() errors.StackTrace
-			}); ok {
-				for _, fr := range st.StackTrace()[1:] {
-					fmt.Fprintf(s, "\n%+v", fr)
-				}
-				return
+			fmt.Fprintf(s, "%s", e.Error())
+			for _, fr := range StackTrace(e) {
+				fmt.Fprintf(s, "\n%s\n\t%s:%d", fr.Function, fr.File, fr.Line)
 			}
+			return
 		}
 		fallthrough
 	case 's':
@@ -92,10 +160,11 @@ func (e *Error) Format(s fmt.State, verb rune) {
 }
 
 func (e *Error) isZero() bool {
-	return e.Is(ErrUndefined) &&
+	return errors.Is(e.Err, ErrUndefined) &&
 		e.User == "" &&
 		e.Param == "" &&
-		e.Code == ""
+		e.Code == "" &&
+		e.Message == ""
 }
 
 const (
@@ -117,42 +186,57 @@ const (
 	// It is used when an authenticated user trying to access the resource
 	// but not permitted to do so
 	Unauthorized
-)
 
-func (k Kind) String() string {
-	switch k {
-	case Other:
-		return "other_error"
-	case IO:
-		return "I/O_error"
-	case Private:
-		return "private"
-	case Internal:
-		return "internal_error"
-	case Database:
-		return "database_error"
-	case Exist:
-		return "resource_already_exists"
-	case NotExist:
-		return "resource_does_not_exist"
-	case Invalid:
-		return "invalid_operation"
-	case Validation:
-		return "input_validation_error"
-	case InvalidRequest:
-		return "invalid_request_error"
-	case Unauthenticated:
-		return "unauthenticated_request"
-	case Unauthorized:
-		return "unauthorized_request"
-	}
-
-	return "unknown_error"
-}
+	RateLimited        // Too many requests, the caller should slow down
+	Conflict           // Conflict with the current state of the resource
+	Timeout            // Operation or upstream dependency timed out
+	Unavailable        // Service or upstream dependency is temporarily unavailable
+	PreconditionFailed // Precondition given by the request is not met
+	PaymentRequired    // Payment is required to proceed
+)
 
 var DefaultRealm Realm = "restricted"
 var ErrUndefined = errors.New("undefined error")
 
+// kindError is the sentinel error of a Kind, see KindError
+type kindError Kind
+
+func (k kindError) Error() string {
+	return Kind(k).String()
+}
+
+// Sentinel errors of the built-in kinds, matching any *Error of that Kind
+// in the chain through errors.Is:
+//
+//	errors.Is(err, errs.ErrNotExist)
+var (
+	ErrIO                 = KindError(IO)
+	ErrPrivate            = KindError(Private)
+	ErrInternal           = KindError(Internal)
+	ErrDatabase           = KindError(Database)
+	ErrExist              = KindError(Exist)
+	ErrNotExist           = KindError(NotExist)
+	ErrInvalid            = KindError(Invalid)
+	ErrValidation         = KindError(Validation)
+	ErrInvalidRequest     = KindError(InvalidRequest)
+	ErrUnauthenticated    = KindError(Unauthenticated)
+	ErrUnauthorized       = KindError(Unauthorized)
+	ErrRateLimited        = KindError(RateLimited)
+	ErrConflict           = KindError(Conflict)
+	ErrTimeout            = KindError(Timeout)
+	ErrUnavailable        = KindError(Unavailable)
+	ErrPreconditionFailed = KindError(PreconditionFailed)
+	ErrPaymentRequired    = KindError(PaymentRequired)
+)
+
+// KindError returns the sentinel error of the given Kind, matching any *Error
+// of that Kind in the chain through errors.Is:
+//
+//	errors.Is(err, errs.KindError(errs.NotExist))
+func KindError(kind Kind) error {
+	return kindError(kind)
+}
+
 // E builds an error value from its arguments.
 // There must be at least one argument or E panics.
 // The type of each argument determines its meaning.
@@ -162,24 +246,44 @@ var ErrUndefined = errors.New("undefined error")
 // The types are:
 //	errs.UserName
 //		The username of the user attempting the operation.
+//	errs.Op
+//		The operation being performed, usually the method
+//		being invoked (user.Create, db.Insert, etc.).
 //	errs.Kind
 //		The class of error, such as permission failure.
 //	errs.Code
 //		The code for a human-readable purpose about the error.
+//		If the code is registered through RegisterCode, its Kind and
+//		Message are used unless given.
 //	errs.Parameter
 //		The parameter represent the parameter related with the error.
+//	errs.Message
+//		The safe, user-facing message rendered to the client
+//		instead of the message of the underlying error.
+//	errs.Fields
+//		The structured metadata attached to the error, multiple
+//		Fields are merged, the later one wins on the same key.
+//	errs.Retry
+//		Whether the failed operation is worth retrying, such as
+//		errs.Retryable or errs.NotRetryable.
+//	errs.RetryAfter
+//		How long to wait before retrying the failed operation.
 //	string
 //		Treated as an error message and assigned to the
 //		Err field after a call to errors.New.
 //	error
-//		The underlying error that triggered this one, if the error not contains stack,
-// 		we will wrap it
+//		The underlying error that triggered this one.
+//	errs.NoStack
+//		Disables the stack capture of this error, such as in hot paths.
 //
 // If the error is printed, only those items that have been
 // set to non-zero values will appear in the result.
 //
 // If Kind is not specified or Other, we set it to the Kind of
 // the underlying error.
+//
+// Unless the underlying error is an *Error, E captures the call stack
+// up to StackDepth frames, see StackTrace.
 func E(args ...interface{}) error {
 
 	if len(args) == 0 {
@@ -187,47 +291,58 @@ func E(args ...interface{}) error {
 	}
 
 	e := &Error{}
+	capture := true
 	for _, arg := range args {
 		switch arg := arg.(type) {
 		case Kind:
 			e.Kind = arg
 		case UserName:
 			e.User = arg
+		case Op:
+			e.Op = arg
 		case Code:
 			e.Code = arg
 		case Parameter:
 			e.Param = arg
+		case Message:
+			e.Message = arg
 		case Realm:
 			e.Realm = arg
+		case Fields:
+			e.Fields = mergeFields(e.Fields, arg)
+		case Retry:
+			e.Retry = arg
+		case RetryAfter:
+			e.RetryAfter = arg
 		case string:
 			e.Err = errors.New(arg)
 		case *Error:
 			e.Err = arg
 		case error:
-			// if the error is validation errors, skipping the stacktrace
-			if verr, ok := arg.(ValidationErrors); ok {
-				e.Err = verr
-				continue
-			}
-
-			// if the error implements stackTracer, then it is
-			// a pkg/errors error type and does not need to have
-			// the stack added
-			_, ok := arg.(interface {
-				StackTrace() errors.StackTrace
-			})
-			if ok {
-				e.Err = arg
-			} else {
-				e.Err = errors.WithStack(arg)
-			}
-
+			e.Err = arg
+		case noStack:
+			capture = false
 		default:
 			_, file, line, _ := runtime.Caller(1)
 			return fmt.Errorf("errs.E: bad call from %s:%d: %v, unknown type %T, value %v in error call", file, line, args, arg, arg)
 		}
 	}
 
+	// If this error has Kind unset or Other, take the most severe Kind of the aggregated errors.
+	if m, ok := e.Err.(MultiError); ok && e.Kind == Other {
+		e.Kind = m.Kind()
+	}
+
+	// If the Code is registered, fill the Kind and Message left unset with its defaults.
+	if info, ok := LookupCode(e.Code); ok {
+		if e.Kind == Other {
+			e.Kind = info.Kind
+		}
+		if e.Message == "" {
+			e.Message = info.Message
+		}
+	}
+
 	// If this error and the inner still has Realm == "", while error Kind is Unauthenticated
 	// then the realm set to default "restricted" method
 	if e.Realm == "" && e.Kind == Unauthenticated {
@@ -240,6 +355,11 @@ func E(args ...interface{}) error {
 
 	prev, ok := e.Err.(*Error)
 	if !ok {
+		// The stack is only captured by the innermost error,
+		// closest to where the failure originates.
+		if capture {
+			e.stack = callers(3)
+		}
 		return e
 	}
 	// If this error has Kind unset or Other, pull up the inner one.
@@ -266,6 +386,15 @@ func E(args ...interface{}) error {
 		prev.Param = ""
 	}
 
+	if prev.Message == e.Message {
+		prev.Message = ""
+	}
+	// If this error has Message == "", pull up the inner one.
+	if e.Message == "" {
+		e.Message = prev.Message
+		prev.Message = ""
+	}
+
 	if prev.Realm == e.Realm {
 		prev.Realm = ""
 	}
@@ -276,9 +405,40 @@ func E(args ...interface{}) error {
 		prev.Realm = ""
 	}
 
+	// If this error has Retry unset, pull up the inner one.
+	if e.Retry == RetryUnset {
+		e.Retry = prev.Retry
+		prev.Retry = RetryUnset
+	}
+
+	// If this error has RetryAfter unset, pull up the inner one.
+	if e.RetryAfter == 0 {
+		e.RetryAfter = prev.RetryAfter
+		prev.RetryAfter = 0
+	}
+
+	// Merge the inner Fields, this error wins on the same key.
+	if len(prev.Fields) > 0 {
+		e.Fields = mergeFields(prev.Fields, e.Fields)
+		prev.Fields = nil
+	}
+
 	return e
 }
 
+// mergeFields returns a new Fields holding the fields of dst overridden by src
+func mergeFields(dst, src Fields) Fields {
+	out := make(Fields, len(dst)+len(src))
+	for k, v := range dst {
+		out[k] = v
+	}
+	for k, v := range src {
+		out[k] = v
+	}
+
+	return out
+}
+
 // Match compares its two error arguments. It can be used to check
 // for expected errors in tests. Both arguments must have underlying
 // type *Error or Match will return false. Otherwise it returns true
@@ -301,6 +461,9 @@ func Match(err1, err2 error) bool {
 	if !ok {
 		return false
 	}
+	if e1.Op != "" && e2.Op != e1.Op {
+		return false
+	}
 	if e1.User != "" && e2.User != e1.User {
 		return false
 	}
@@ -313,6 +476,9 @@ func Match(err1, err2 error) bool {
 	if e1.Code != "" && e2.Code != e1.Code {
 		return false
 	}
+	if e1.Message != "" && e2.Message != e1.Message {
+		return false
+	}
 	if e1.Err != nil {
 		if _, ok := e1.Err.(*Error); ok {
 			return Match(e1.Err, e2.Err)
@@ -324,13 +490,54 @@ func Match(err1, err2 error) bool {
 	return true
 }
 
-// KindIs reports whether err is an *Error of the given Kind.
-// If err is nil then KindIs returns false.
+// Ops returns the trail of operations recorded along the chain of given error,
+// from the outermost to the innermost one.
+func Ops(err error) []Op {
+	var ops []Op
+	for err != nil {
+		e, ok := err.(*Error)
+		if !ok {
+			err = errors.Unwrap(err)
+			continue
+		}
+
+		if e.Op != "" {
+			ops = append(ops, e.Op)
+		}
+		err = e.Err
+	}
+
+	return ops
+}
+
+// KindIs reports whether the effective Kind of err is the given Kind, see KindOf.
+// If err is nil, or there is no *Error in its chain, then KindIs returns false.
 func KindIs(kind Kind, err error) bool {
-	e, ok := err.(*Error)
-	if !ok {
+	var e *Error
+	if !errors.As(err, &e) {
 		return false
 	}
 
-	return e.Kind == kind
+	return KindOf(err) == kind
+}
+
+// KindOf returns the effective Kind of err, which is the first Kind other than Other
+// found along its chain, such as through fmt.Errorf wrapping. The Kind of a MultiError is
+// its most severe one, see MultiError.Kind. If none is found, KindOf returns Other.
+func KindOf(err error) Kind {
+	for err != nil {
+		switch e := err.(type) {
+		case *Error:
+			if e.Kind != Other {
+				return e.Kind
+			}
+			err = e.Err
+		case MultiError:
+			return e.Kind()
+		default:
+			err = errors.Unwrap(err)
+		}
+	}
+
+	return Other
 }
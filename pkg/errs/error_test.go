@@ -3,6 +3,7 @@ package errs_test
 import (
 	"errors"
 	"fmt"
+	"io"
 	"testing"
 
 	"github.com/ardikabs/golib/pkg/errs"
@@ -86,6 +87,21 @@ func TestKindIs(t *testing.T) {
 			kind: errs.Other,
 			want: true,
 		},
+		{
+			err:  fmt.Errorf("wrapped: %w", errs.E(errs.NotExist, "not exist")),
+			kind: errs.NotExist,
+			want: true,
+		},
+		{
+			err:  errs.E(errs.Op("user.Get"), fmt.Errorf("repo: %w", errs.E(errs.NotExist, "not exist"))),
+			kind: errs.NotExist,
+			want: true,
+		},
+		{
+			err:  fmt.Errorf("plain error"),
+			kind: errs.Other,
+			want: false,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -137,3 +153,215 @@ func TestUnauthenticatedE(t *testing.T) {
 		assert.Equal(t, errs.Realm("admin"), e.Realm)
 	})
 }
+
+func TestParseKind(t *testing.T) {
+	for _, k := range []errs.Kind{errs.Other, errs.IO, errs.NotExist, errs.Validation, errs.Unauthorized} {
+		got, err := errs.ParseKind(k.String())
+		assert.NoError(t, err)
+		assert.Equal(t, k, got)
+	}
+
+	_, err := errs.ParseKind("unknown_error")
+	assert.Error(t, err)
+}
+
+func TestOps(t *testing.T) {
+	inner := errs.E(errs.Op("db.Insert"), errs.Database, fmt.Errorf("duplicate key"))
+	err := errs.E(errs.Op("user.Create"), inner)
+
+	assert.Equal(t, "user.Create: db.Insert: duplicate key", err.Error())
+	assert.Equal(t, []errs.Op{"user.Create", "db.Insert"}, errs.Ops(err))
+	assert.Equal(t, []errs.Op{"user.Create", "db.Insert"}, errs.Ops(fmt.Errorf("handler: %w", err)))
+	assert.True(t, errs.KindIs(errs.Database, err))
+
+	assert.Nil(t, errs.Ops(nil))
+	assert.Nil(t, errs.Ops(errs.E("no op")))
+
+	assert.True(t, errs.Match(errs.E(errs.Op("user.Create"), errs.E(errs.Op("db.Insert"), "duplicate key")), err))
+	assert.False(t, errs.Match(errs.E(errs.Op("user.Update"), errs.E(errs.Op("db.Insert"), "duplicate key")), err))
+}
+
+func TestFields(t *testing.T) {
+	inner := errs.E(errs.Database, errs.Field("entity_id", 14), errs.Field("tenant_id", "acme"), "duplicate key")
+	err := errs.E(errs.Field("request_id", "req-1"), errs.Field("tenant_id", "umbrella"), inner)
+
+	e, ok := err.(*errs.Error)
+	assert.True(t, ok)
+	assert.Equal(t, errs.Fields{"request_id": "req-1", "entity_id": 14, "tenant_id": "umbrella"}, e.Fields)
+	assert.Nil(t, inner.(*errs.Error).Fields)
+}
+
+func TestUnwrap(t *testing.T) {
+	cause := errors.New("sql: no rows")
+
+	t.Run("direct cause", func(t *testing.T) {
+		assert.Equal(t, cause, errors.Unwrap(errs.E(errs.NotExist, cause)))
+	})
+
+	t.Run("nested error", func(t *testing.T) {
+		err := errs.E(errs.Op("user.Get"), errs.E(errs.Op("db.Get"), errs.NotExist, cause))
+
+		inner, ok := errors.Unwrap(err).(*errs.Error)
+		assert.True(t, ok)
+		assert.Equal(t, errs.Op("db.Get"), inner.Op)
+		assert.Equal(t, cause, errors.Unwrap(inner))
+	})
+
+	t.Run("undefined error", func(t *testing.T) {
+		assert.Equal(t, errs.ErrUndefined, errors.Unwrap(errs.E(errs.NotExist)))
+	})
+}
+
+func TestErrorIs(t *testing.T) {
+	cause := errors.New("sql: no rows")
+	other := errors.New("other")
+
+	direct := errs.E(errs.NotExist, cause)
+	nested := errs.E(errs.Op("user.Get"), errs.E(errs.Op("db.Get"), errs.NotExist, cause))
+	wrapped := fmt.Errorf("handler: %w", nested)
+	behindFmt := errs.E(errs.Op("user.Get"), fmt.Errorf("repo: %w", errs.E(errs.NotExist, cause)))
+	multi := errs.Join(errs.E(errs.Conflict, "a"), errs.E(errs.NotExist, cause))
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{"direct cause", direct, cause, true},
+		{"direct other", direct, other, false},
+		{"direct kind", direct, errs.KindError(errs.NotExist), true},
+		{"direct other kind", direct, errs.KindError(errs.Internal), false},
+		{"direct itself", direct, direct, true},
+
+		{"nested cause", nested, cause, true},
+		{"nested kind", nested, errs.KindError(errs.NotExist), true},
+		{"nested inner error", nested, errors.Unwrap(nested), true},
+
+		{"wrapped cause", wrapped, cause, true},
+		{"wrapped kind", wrapped, errs.KindError(errs.NotExist), true},
+		{"wrapped other kind", wrapped, errs.KindError(errs.Conflict), false},
+
+		{"behind fmt cause", behindFmt, cause, true},
+		{"behind fmt kind", behindFmt, errs.KindError(errs.NotExist), true},
+
+		{"multiple errors cause", multi, cause, true},
+		{"multiple errors kind", multi, errs.KindError(errs.NotExist), true},
+		{"multiple errors most severe kind", multi, errs.KindError(errs.Conflict), true},
+
+		{"plain error kind", cause, errs.KindError(errs.Other), false},
+		{"undefined", errs.E(errs.NotExist), errs.ErrUndefined, true},
+		{"nil", nil, errs.KindError(errs.NotExist), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errors.Is(tt.err, tt.target))
+		})
+	}
+}
+
+type testPathError struct {
+	path string
+}
+
+func (e *testPathError) Error() string {
+	return "bad path " + e.path
+}
+
+func TestErrorAs(t *testing.T) {
+	cause := &testPathError{path: "/tmp"}
+
+	tests := []struct {
+		name     string
+		err      error
+		wantOp   errs.Op
+		wantPath bool
+	}{
+		{"direct", errs.E(errs.Op("file.Open"), errs.IO, cause), "file.Open", true},
+		{"nested", errs.E(errs.Op("user.Load"), errs.E(errs.Op("file.Open"), errs.IO, cause)), "user.Load", true},
+		{"wrapped", fmt.Errorf("handler: %w", errs.E(errs.Op("user.Load"), errs.E(errs.Op("file.Open"), cause))), "user.Load", true},
+		{"behind fmt", errs.E(errs.Op("user.Load"), fmt.Errorf("repo: %w", errs.E(errs.Op("file.Open"), cause))), "user.Load", true},
+		{"no path error", errs.E(errs.Op("user.Load"), "failed"), "user.Load", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e *errs.Error
+			assert.True(t, errors.As(tt.err, &e))
+			assert.Equal(t, tt.wantOp, e.Op, "outermost *Error")
+
+			var pe *testPathError
+			assert.Equal(t, tt.wantPath, errors.As(tt.err, &pe))
+			if tt.wantPath {
+				assert.Equal(t, cause, pe)
+			}
+		})
+	}
+
+	t.Run("not an *Error", func(t *testing.T) {
+		var e *errs.Error
+		assert.False(t, errors.As(cause, &e))
+		assert.False(t, errors.As(fmt.Errorf("wrapped: %w", cause), &e))
+	})
+}
+
+func TestKindError(t *testing.T) {
+	assert.Equal(t, errs.KindError(errs.NotExist), errs.KindError(errs.NotExist))
+	assert.NotEqual(t, errs.KindError(errs.NotExist), errs.KindError(errs.Exist))
+	assert.Equal(t, "resource_does_not_exist", errs.KindError(errs.NotExist).Error())
+}
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want errs.Kind
+	}{
+		{"nil", nil, errs.Other},
+		{"plain error", fmt.Errorf("plain error"), errs.Other},
+		{"direct", errs.E(errs.NotExist, "not exist"), errs.NotExist},
+		{"nested", errs.E(errs.Op("user.Get"), errs.E(errs.NotExist, "not exist")), errs.NotExist},
+		{"wrapped", fmt.Errorf("wrapped: %w", errs.E(errs.NotExist, "not exist")), errs.NotExist},
+		{"behind fmt", errs.E(errs.Op("user.Get"), fmt.Errorf("repo: %w", errs.E(errs.NotExist, "not exist"))), errs.NotExist},
+		{"outermost wins", errs.E(errs.Internal, fmt.Errorf("repo: %w", errs.E(errs.NotExist, "not exist"))), errs.Internal},
+		{"multiple errors", fmt.Errorf("wrapped: %w", errs.Join(errs.E(errs.NotExist, "a"), errs.E(errs.Conflict, "b"))), errs.Conflict},
+		{"unset", errs.E("some error"), errs.Other},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errs.KindOf(tt.err))
+		})
+	}
+}
+
+func TestKindSentinels(t *testing.T) {
+	err := fmt.Errorf("handler: %w", errs.E(errs.Op("user.Get"), fmt.Errorf("repo: %w", errs.E(errs.NotExist, "not exist"))))
+
+	assert.True(t, errors.Is(err, errs.ErrNotExist))
+	assert.False(t, errors.Is(err, errs.ErrExist))
+	assert.False(t, errors.Is(fmt.Errorf("not exist"), errs.ErrNotExist))
+	assert.Equal(t, errs.KindError(errs.NotExist), errs.ErrNotExist)
+}
+
+func TestDetail(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *errs.Error
+		want string
+	}{
+		{"no op", errs.E(errs.NotExist, "not exist").(*errs.Error), "not exist"},
+		{"op trail", errs.E(errs.Op("user.Create"), errs.E(errs.Op("db.Insert"), "duplicate key")).(*errs.Error), "duplicate key"},
+		{"multiple errors", errs.E(errs.Op("user.Import"), errs.Join(errs.E(errs.Op("db.Insert"), "a"), fmt.Errorf("b"))).(*errs.Error), "a; b"},
+		{"undefined", errs.E(errs.Op("user.Create")).(*errs.Error), "undefined error"},
+		{"op behind a wrap", errs.E(errs.Op("user.Get"), fmt.Errorf("repo: %w", errs.E(errs.Op("db.Get"), errs.NotExist, "x"))).(*errs.Error), "x"},
+		{"plain wrap", errs.E(errs.Op("user.Get"), fmt.Errorf("repo: %w", io.EOF)).(*errs.Error), "repo: EOF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.err.Detail())
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package errs
+
+import "time"
+
+// SetClock replaces the clock of the LogSampler, for the tests to control the time
+func (s *LogSampler) SetClock(now func() time.Time, afterFunc func(time.Duration, func())) {
+	s.now = now
+	s.afterFunc = afterFunc
+}
+
+// Len returns the number of fingerprints tracked by the LogSampler
+func (s *LogSampler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.windows)
+}
@@ -0,0 +1,28 @@
+module github.com/ardikabs/golib/pkg/errs/grpcerrs
+
+go 1.18
+
+require (
+	github.com/ardikabs/golib v0.0.0-20261015161723-b74597494fd4
+	github.com/stretchr/testify v1.8.0
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013
+	google.golang.org/grpc v1.50.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.16 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/rs/zerolog v1.28.0 // indirect
+	golang.org/x/net v0.0.0-20201021035429-f5854403a974 // indirect
+	golang.org/x/sys v0.0.0-20220919091848-fb04ddd9f9c8 // indirect
+	golang.org/x/text v0.3.7 // indirect
+	google.golang.org/protobuf v1.27.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The replace only serves the local development against the enclosing module,
+// it is ignored when this module is required by another one.
+replace github.com/ardikabs/golib => ../../..
@@ -0,0 +1,249 @@
+// Package grpcerrs translates errs.Error from and into gRPC status.
+//
+// It lives in its own module, so the gRPC dependency is only pulled
+// by the services that serve or consume gRPC.
+package grpcerrs
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataParam is the ErrorInfo metadata key carrying the errs.Parameter
+const MetadataParam = "param"
+
+// CodeFromKind translate error kind to a gRPC status code,
+// as declared by the errs.KindInfo of the kind. It never returns codes.OK,
+// as the kind is of an error.
+func CodeFromKind(k errs.Kind) codes.Code {
+	if info, ok := errs.LookupKind(k); ok && codes.Code(info.GRPCCode) != codes.OK {
+		return codes.Code(info.GRPCCode)
+	}
+
+	return codes.Unknown
+}
+
+// KindFromCode translate gRPC status code to an error kind.
+// The kind is refined by the ErrorInfo detail, if any, see FromStatus.
+func KindFromCode(c codes.Code) errs.Kind {
+	switch c {
+	case codes.Unavailable:
+		return errs.Unavailable
+	case codes.ResourceExhausted:
+		return errs.RateLimited
+	case codes.Aborted:
+		return errs.Conflict
+	case codes.DeadlineExceeded:
+		return errs.Timeout
+	case codes.Internal, codes.DataLoss:
+		return errs.Internal
+	case codes.AlreadyExists:
+		return errs.Exist
+	case codes.NotFound:
+		return errs.NotExist
+	case codes.FailedPrecondition:
+		return errs.Invalid
+	case codes.InvalidArgument, codes.OutOfRange:
+		return errs.InvalidRequest
+	case codes.Unauthenticated:
+		return errs.Unauthenticated
+	case codes.PermissionDenied:
+		return errs.Unauthorized
+	default:
+		return errs.Other
+	}
+}
+
+// Status translates given error into a gRPC status.
+// An *errs.Error carries its Code and Param as ErrorInfo details,
+// and its ValidationErrors as BadRequest field violations.
+// The status message is the errs.Message if set, otherwise messages of Internal, Database
+// and IO errors, and of errors that are not an *errs.Error, are hidden,
+// the same as errs.HTTPRequestErrorHandler does.
+func Status(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	var e *errs.Error
+	if !errors.As(err, &e) {
+		return status.New(codes.Unknown, "unknown error - please contact support")
+	}
+
+	kind := errs.KindOf(err)
+	code := CodeFromKind(kind)
+
+	message := string(e.Message)
+	if message == "" {
+		switch kind {
+		case errs.Internal, errs.Database, errs.IO:
+			message = "internal server error"
+		default:
+			message = e.Detail()
+		}
+	}
+
+	st := status.New(code, message)
+
+	info := &errdetails.ErrorInfo{
+		Reason:   string(e.Code),
+		Domain:   kind.String(),
+		Metadata: map[string]string{},
+	}
+	if e.Param != "" {
+		info.Metadata[MetadataParam] = string(e.Param)
+	}
+
+	withDetails, derr := st.WithDetails(info)
+	if derr != nil {
+		return st
+	}
+	st = withDetails
+
+	var verr errs.ValidationErrors
+	if errors.As(err, &verr) {
+		br := &errdetails.BadRequest{}
+		for _, err := range verr {
+			ie, ok := err.(*errs.Error)
+			if !ok {
+				continue
+			}
+
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       string(ie.Param),
+				Description: validationDescription(ie),
+			})
+		}
+
+		if withDetails, derr := st.WithDetails(br); derr == nil {
+			st = withDetails
+		}
+	}
+
+	return st
+}
+
+// FromStatus translates given gRPC status back into an *errs.Error.
+// It returns nil when the status code is OK.
+func FromStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	args := []interface{}{KindFromCode(st.Code())}
+
+	var verr errs.ValidationErrors
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if k, err := errs.ParseKind(d.Domain); err == nil {
+				args[0] = k
+			}
+			if d.Reason != "" {
+				args = append(args, errs.Code(d.Reason))
+			}
+			if param := d.Metadata[MetadataParam]; param != "" {
+				args = append(args, errs.Parameter(param))
+			}
+		case *errdetails.BadRequest:
+			for _, fv := range d.FieldViolations {
+				verr = append(verr, errs.E(errs.Parameter(fv.Field), fv.Description))
+			}
+		}
+	}
+
+	if len(verr) > 0 {
+		args[0] = errs.Validation
+		return errs.E(append(args, verr)...)
+	}
+
+	return errs.E(append(args, st.Message())...)
+}
+
+// FromError translates an error returned by a gRPC call back into an *errs.Error.
+// Errors that are not a gRPC status are returned as is.
+func FromError(err error) error {
+	if err == nil || errors.Is(err, io.EOF) {
+		return err
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	return FromStatus(st)
+}
+
+// UnaryServerInterceptor translates the error returned by the handler into a gRPC status error
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, Status(err).Err()
+		}
+
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor translates the error returned by the handler into a gRPC status error
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return Status(err).Err()
+		}
+
+		return nil
+	}
+}
+
+// UnaryClientInterceptor translates the gRPC status error returned by the call back into an *errs.Error
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return FromError(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor translates the gRPC status errors returned by the stream back into an *errs.Error
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, FromError(err)
+		}
+
+		return &clientStream{ClientStream: cs}, nil
+	}
+}
+
+type clientStream struct {
+	grpc.ClientStream
+}
+
+func (s *clientStream) SendMsg(m interface{}) error {
+	return FromError(s.ClientStream.SendMsg(m))
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	return FromError(s.ClientStream.RecvMsg(m))
+}
+
+func validationDescription(e *errs.Error) string {
+	if e.Message != "" {
+		return string(e.Message)
+	}
+
+	return e.Detail()
+}
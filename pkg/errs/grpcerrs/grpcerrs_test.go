@@ -0,0 +1,182 @@
+package grpcerrs_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/ardikabs/golib/pkg/errs/grpcerrs"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var quotaExceeded = errs.RegisterKind(errs.KindInfo{Name: "grpcerrs_test_quota_exceeded", HTTPStatus: 403})
+
+func TestCodeFromKind(t *testing.T) {
+	tests := []struct {
+		kind errs.Kind
+		want codes.Code
+	}{
+		{errs.NotExist, codes.NotFound},
+		{errs.Validation, codes.InvalidArgument},
+		{errs.Unauthenticated, codes.Unauthenticated},
+		{errs.Unauthorized, codes.PermissionDenied},
+		{errs.Exist, codes.AlreadyExists},
+		{errs.Database, codes.Internal},
+		{errs.RateLimited, codes.ResourceExhausted},
+		{errs.Timeout, codes.DeadlineExceeded},
+		{errs.Other, codes.Unknown},
+		{errs.Kind(200), codes.Unknown},
+		{quotaExceeded, codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, grpcerrs.CodeFromKind(tt.kind))
+		})
+	}
+}
+
+func TestStatus_RegisteredKindWithoutCode(t *testing.T) {
+	st := grpcerrs.Status(errs.E(quotaExceeded, "quota exceeded"))
+	assert.Equal(t, codes.Unknown, st.Code())
+	assert.Error(t, st.Err())
+}
+
+func TestStatus_KindBehindAWrap(t *testing.T) {
+	st := grpcerrs.Status(errs.E(errs.Op("user.Get"), fmt.Errorf("repo: %w", errs.E(errs.NotExist, "x"))))
+	assert.Equal(t, codes.NotFound, st.Code())
+}
+
+func TestStatusRoundTrip(t *testing.T) {
+	t.Run("common error", func(t *testing.T) {
+		err := errs.E(errs.NotExist, errs.Code("product_not_exist"), errs.Parameter("id"), "product not exist")
+
+		st := grpcerrs.Status(err)
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "product not exist", st.Message())
+
+		got := grpcerrs.FromError(st.Err())
+		assert.True(t, errs.Match(errs.E(errs.NotExist, errs.Code("product_not_exist"), errs.Parameter("id"), "product not exist"), got))
+	})
+
+	t.Run("operations are left out", func(t *testing.T) {
+		err := errs.E(errs.Op("user.Create"), errs.E(errs.Op("db.Insert"), errs.Exist, "duplicate key"))
+
+		st := grpcerrs.Status(err)
+		assert.Equal(t, "duplicate key", st.Message())
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		err := errs.E(errs.Validation, errs.ValidationErrors{
+			errs.E(errs.Parameter("key"), "bad format"),
+		})
+
+		st := grpcerrs.Status(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+
+		got := grpcerrs.FromError(st.Err())
+		assert.True(t, errs.KindIs(errs.Validation, got))
+		assert.Equal(t, "key: bad format", got.(*errs.Error).Err.Error())
+	})
+
+	t.Run("hidden message", func(t *testing.T) {
+		st := grpcerrs.Status(errs.E(errs.Database, "duplicate key"))
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.Equal(t, "internal server error", st.Message())
+	})
+
+	t.Run("unknown error", func(t *testing.T) {
+		st := grpcerrs.Status(fmt.Errorf(`pq: password authentication failed for user "billing"`))
+		assert.Equal(t, codes.Unknown, st.Code())
+		assert.Equal(t, "unknown error - please contact support", st.Message())
+	})
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	intercept := grpcerrs.UnaryServerInterceptor()
+
+	resp, err := intercept(context.Background(), "req", &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errs.E(errs.NotExist, "product not exist")
+	})
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	resp, err = intercept(context.Background(), "req", &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "resp", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "resp", resp)
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	intercept := grpcerrs.StreamServerInterceptor()
+
+	err := intercept(nil, nil, &grpc.StreamServerInfo{}, func(srv interface{}, ss grpc.ServerStream) error {
+		return errs.E(errs.Unauthenticated, "token expired")
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	assert.NoError(t, intercept(nil, nil, &grpc.StreamServerInfo{}, func(srv interface{}, ss grpc.ServerStream) error {
+		return nil
+	}))
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	intercept := grpcerrs.UnaryClientInterceptor()
+
+	err := intercept(context.Background(), "/product.Service/Get", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return grpcerrs.Status(errs.E(errs.NotExist, errs.Code("product_not_exist"), "product not exist")).Err()
+	})
+	assert.True(t, errs.KindIs(errs.NotExist, err))
+	assert.Equal(t, errs.Code("product_not_exist"), err.(*errs.Error).Code)
+
+	plain := fmt.Errorf("dial failed")
+	assert.Equal(t, plain, intercept(context.Background(), "/product.Service/Get", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return plain
+	}))
+}
+
+// fakeClientStream fails every message with its err
+type fakeClientStream struct {
+	grpc.ClientStream
+	err error
+}
+
+func (s fakeClientStream) SendMsg(m interface{}) error { return s.err }
+func (s fakeClientStream) RecvMsg(m interface{}) error { return s.err }
+
+func TestStreamClientInterceptor(t *testing.T) {
+	intercept := grpcerrs.StreamClientInterceptor()
+
+	streamer := func(err error) grpc.Streamer {
+		return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return fakeClientStream{err: err}, nil
+		}
+	}
+
+	t.Run("stream error", func(t *testing.T) {
+		_, err := intercept(context.Background(), &grpc.StreamDesc{}, nil, "/product.Service/List", func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return nil, status.Error(codes.Unavailable, "connection refused")
+		})
+		assert.True(t, errs.KindIs(errs.Unavailable, err))
+	})
+
+	t.Run("message error", func(t *testing.T) {
+		cs, err := intercept(context.Background(), &grpc.StreamDesc{}, nil, "/product.Service/List", streamer(grpcerrs.Status(errs.E(errs.Timeout, "timed out")).Err()))
+		assert.NoError(t, err)
+
+		assert.True(t, errs.KindIs(errs.Timeout, cs.SendMsg(nil)))
+		assert.True(t, errs.KindIs(errs.Timeout, cs.RecvMsg(nil)))
+	})
+
+	t.Run("end of stream", func(t *testing.T) {
+		cs, err := intercept(context.Background(), &grpc.StreamDesc{}, nil, "/product.Service/List", streamer(io.EOF))
+		assert.NoError(t, err)
+		assert.Equal(t, io.EOF, cs.RecvMsg(nil))
+	})
+}
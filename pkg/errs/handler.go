@@ -0,0 +1,462 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// StatusMapper translates an error Kind into an HTTP status code
+type StatusMapper func(k Kind) int
+
+// LogHook logs the error handled by HTTPHandler along with the HTTP status code responded.
+// The err may be nil, or an error that is not an *Error.
+type LogHook func(lgr zerolog.Logger, status int, err error)
+
+// HandlerOption configures the HTTPHandler
+type HandlerOption func(*HTTPHandler)
+
+// HTTPHandler translates an error into a structured HTTP response and logs it.
+// The zero value is not usable, use NewHTTPHandler instead.
+type HTTPHandler struct {
+	statusMapper  StatusMapper
+	renderer      Renderer
+	renderers     []Renderer
+	logHook       LogHook
+	slogLogHook   interface{} // a SlogLogHook, which is only declared along with log/slog
+	hiddenKinds   map[Kind]bool
+	unknownStatus int
+	catalog       *Catalog
+	logLevels     map[Kind]zerolog.Level
+	logSampler    *LogSampler
+	reporter      Reporter
+	reportKinds   map[Kind]bool
+}
+
+// NewHTTPHandler returns a new HTTPHandler, configured with given options.
+// Without options it behaves the same as HTTPRequestErrorHandler.
+func NewHTTPHandler(opts ...HandlerOption) *HTTPHandler {
+	h := &HTTPHandler{
+		statusMapper:  HTTPStatusCodeFromKind,
+		hiddenKinds:   map[Kind]bool{Internal: true, Database: true, IO: true},
+		unknownStatus: http.StatusNotImplemented,
+	}
+	h.logHook = h.defaultLogHook
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// WithStatusMapper sets a custom Kind to HTTP status code mapping
+func WithStatusMapper(fn StatusMapper) HandlerOption {
+	return func(h *HTTPHandler) {
+		h.statusMapper = fn
+	}
+}
+
+// WithRenderer sets the renderer used to write the response body,
+// overriding the DefaultRenderer
+func WithRenderer(r Renderer) HandlerOption {
+	return func(h *HTTPHandler) {
+		h.renderer = r
+	}
+}
+
+// WithRenderers registers renderers to be negotiated against the request Accept header.
+// The renderer set through WithRenderer, or the DefaultRenderer, remains the fallback.
+func WithRenderers(rs ...Renderer) HandlerOption {
+	return func(h *HTTPHandler) {
+		h.renderers = append(h.renderers, rs...)
+	}
+}
+
+// WithLogHook replaces the default logging of the handled error
+func WithLogHook(fn LogHook) HandlerOption {
+	return func(h *HTTPHandler) {
+		h.logHook = fn
+	}
+}
+
+// WithHiddenKinds sets the error kinds which message is hidden from the response body,
+// replacing the default of Internal, Database and IO
+func WithHiddenKinds(kinds ...Kind) HandlerOption {
+	return func(h *HTTPHandler) {
+		h.hiddenKinds = make(map[Kind]bool, len(kinds))
+		for _, k := range kinds {
+			h.hiddenKinds[k] = true
+		}
+	}
+}
+
+// WithUnknownStatus sets the HTTP status code responded for errors that are not an *Error
+func WithUnknownStatus(code int) HandlerOption {
+	return func(h *HTTPHandler) {
+		h.unknownStatus = code
+	}
+}
+
+// WithCatalog localizes the messages of errors having a Code through the given catalog,
+// picking the locale from the request Accept-Language header
+func WithCatalog(c *Catalog) HandlerOption {
+	return func(h *HTTPHandler) {
+		h.catalog = c
+	}
+}
+
+// Handle translates given error into a structured response and logs it.
+// If r is not nil, the response body is negotiated against the request Accept header.
+func (h *HTTPHandler) Handle(w http.ResponseWriter, r *http.Request, lgr zerolog.Logger, err error) {
+	h.handle(w, r, h.zerologLog(lgr), err, false)
+}
+
+// handlerLog logs on behalf of HTTPHandler, through either zerolog or slog
+type handlerLog struct {
+	// hook logs the handled error, it is nil when the error is not logged
+	hook func(status int, err error)
+	// failure logs a failure of handling the error, such as an unrenderable response
+	failure func(msg string, err error)
+	// suppressed logs the summary of similar errors suppressed by the LogSampler
+	suppressed func(fingerprint string, n int, since time.Time)
+}
+
+// zerologLog returns the handlerLog logging through given zerolog.Logger
+func (h *HTTPHandler) zerologLog(lgr zerolog.Logger) handlerLog {
+	lg := handlerLog{
+		failure: func(msg string, err error) {
+			lgr.Error().Stack().Err(err).Msg(msg)
+		},
+		suppressed: func(fingerprint string, n int, since time.Time) {
+			lgr.Warn().
+				Str("fingerprint", fingerprint).
+				Int("suppressed", n).
+				Time("since", since).
+				Msgf("suppressed %d similar errors", n)
+		},
+	}
+
+	if h.logHook != nil {
+		lg.hook = func(status int, err error) { h.logHook(lgr, status, err) }
+	}
+
+	return lg
+}
+
+// handle is Handle, forceReport reports the error regardless of its kind, such as for recovered panics
+func (h *HTTPHandler) handle(w http.ResponseWriter, r *http.Request, lg handlerLog, err error, forceReport bool) {
+	status := h.statusCode(err)
+	if lg.hook != nil && h.logSampled(lg, err) {
+		lg.hook(status, err)
+	}
+	h.report(r, status, err, forceReport)
+
+	if h.catalog != nil && r != nil {
+		w.Header().Add("Vary", "Accept-Language")
+	}
+
+	if d, ok := RetryAfterOf(err); ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+	}
+
+	if m, kind, ok := multiErrorOf(err); ok {
+		h.multiErrHandler(w, r, lg, status, kind, m)
+		return
+	}
+
+	var e *Error
+	switch {
+	case err == nil:
+		w.WriteHeader(status)
+	case errors.As(err, &e):
+		switch kind := KindOf(err); kind {
+		case Validation:
+			h.validationErrHandler(w, r, lg, status, err)
+		case Unauthenticated:
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s"`, realmOf(err)))
+			w.WriteHeader(status)
+		case Unauthorized:
+			w.WriteHeader(status)
+		default:
+			h.commonErrHandler(w, r, lg, status, kind, e)
+		}
+	default:
+		h.write(w, r, lg, status, Other, HTTPErrResponse{
+			Error: &ServiceError{
+				Code:    "unknown_error",
+				Message: "unknown error - please contact support",
+			},
+		})
+	}
+}
+
+// statusCode returns the HTTP status code responded for the given error
+func (h *HTTPHandler) statusCode(err error) int {
+	if err == nil {
+		return http.StatusInternalServerError
+	}
+
+	if _, kind, ok := multiErrorOf(err); ok {
+		return h.statusMapper(kind)
+	}
+
+	var e *Error
+	if !errors.As(err, &e) {
+		return h.unknownStatus
+	}
+
+	if e.isZero() {
+		return http.StatusInternalServerError
+	}
+
+	kind := KindOf(err)
+	if kind == Validation {
+		if _, ok := validationErrorsOf(err); !ok {
+			return http.StatusInternalServerError
+		}
+	}
+
+	return h.statusMapper(kind)
+}
+
+// validationErrorsOf returns the ValidationErrors carried along the chain of err
+func validationErrorsOf(err error) (ValidationErrors, bool) {
+	var verr ValidationErrors
+	return verr, errors.As(err, &verr)
+}
+
+// realmOf returns the first Realm set along the chain of err
+func realmOf(err error) Realm {
+	for err != nil {
+		var e *Error
+		if !errors.As(err, &e) {
+			break
+		}
+
+		if e.Realm != "" {
+			return e.Realm
+		}
+		err = e.Err
+	}
+
+	return ""
+}
+
+func (h *HTTPHandler) commonErrHandler(w http.ResponseWriter, r *http.Request, lg handlerLog, status int, kind Kind, e *Error) {
+	if e.isZero() {
+		w.WriteHeader(status)
+		return
+	}
+
+	// The message of hidden kinds is only rendered when it is set
+	// explicitly through Message or the catalog, which are safe by definition.
+	if h.hiddenKinds[kind] {
+		h.write(w, r, lg, status, kind, HTTPErrResponse{
+			Error: &ServiceError{
+				Kind:    kind.String(),
+				Message: h.message(w, r, e, "internal server error"),
+			},
+		})
+		return
+	}
+
+	h.write(w, r, lg, status, kind, HTTPErrResponse{
+		Error: &ServiceError{
+			Kind:    kind.String(),
+			Code:    string(e.Code),
+			Param:   string(e.Param),
+			Pointer: e.Param.Pointer(),
+			Message: h.message(w, r, e, e.Detail()),
+			DocsURL: codeDocsURL(e.Code),
+		},
+	})
+}
+
+func (h *HTTPHandler) validationErrHandler(w http.ResponseWriter, r *http.Request, lg handlerLog, status int, err error) {
+	verr, ok := validationErrorsOf(err)
+	if !ok {
+		w.WriteHeader(status)
+		return
+	}
+
+	var errs []ServiceError
+	for _, err := range verr {
+		ie, ok := err.(*Error)
+		if !ok {
+			lg.failure("input validation error - unexpected error", redacted(err))
+			continue
+		}
+
+		errs = append(errs, ServiceError{
+			Code:    string(ie.Code),
+			Param:   string(ie.Param),
+			Pointer: ie.Param.Pointer(),
+			Message: h.message(w, r, ie, ie.Detail()),
+			DocsURL: codeDocsURL(ie.Code),
+		})
+	}
+
+	h.write(w, r, lg, status, Validation, HTTPErrResponse{
+		Errors: errs,
+	})
+}
+
+func (h *HTTPHandler) multiErrHandler(w http.ResponseWriter, r *http.Request, lg handlerLog, status int, kind Kind, m MultiError) {
+	errs := make([]ServiceError, 0, len(m))
+	for _, err := range m {
+		var e *Error
+		if !errors.As(err, &e) {
+			errs = append(errs, ServiceError{
+				Code:    "unknown_error",
+				Message: "unknown error - please contact support",
+			})
+			continue
+		}
+
+		k := KindOf(err)
+		if h.hiddenKinds[k] {
+			errs = append(errs, ServiceError{
+				Kind:    k.String(),
+				Message: h.message(w, r, e, "internal server error"),
+			})
+			continue
+		}
+
+		errs = append(errs, ServiceError{
+			Kind:    k.String(),
+			Code:    string(e.Code),
+			Param:   string(e.Param),
+			Pointer: e.Param.Pointer(),
+			Message: h.message(w, r, e, e.Detail()),
+			DocsURL: codeDocsURL(e.Code),
+		})
+	}
+
+	h.write(w, r, lg, status, kind, HTTPErrResponse{
+		Errors: errs,
+	})
+}
+
+// message returns the message rendered for e, which is the localized message from the catalog,
+// then the Message, then the given fallback, whichever is available first, redacted by DefaultRedactor
+func (h *HTTPHandler) message(w http.ResponseWriter, r *http.Request, e *Error, fallback string) string {
+	return DefaultRedactor.Message(h.rawMessage(w, r, e, fallback))
+}
+
+func (h *HTTPHandler) rawMessage(w http.ResponseWriter, r *http.Request, e *Error, fallback string) string {
+	if h.catalog != nil && e.Code != "" {
+		var acceptLanguage string
+		if r != nil {
+			acceptLanguage = r.Header.Get("Accept-Language")
+		}
+
+		if msg, locale, ok := h.catalog.lookup(h.catalog.Match(acceptLanguage), e); ok {
+			w.Header().Set("Content-Language", locale)
+			return msg
+		}
+	}
+
+	if e.Message != "" {
+		return string(e.Message)
+	}
+
+	return fallback
+}
+
+func (h *HTTPHandler) write(w http.ResponseWriter, r *http.Request, lg handlerLog, status int, kind Kind, resp HTTPErrResponse) {
+	rd := h.negotiate(w, r)
+	body, err := rd.Render(status, kind, resp)
+	if err != nil {
+		lg.failure("unable to render error response", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", rd.ContentType())
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// defaultLogHook is the LogHook used by HTTPHandler unless replaced through WithLogHook,
+// errors are logged at the level set by WithLogLevels for their kind
+func (h *HTTPHandler) defaultLogHook(lgr zerolog.Logger, status int, err error) {
+	if err == nil {
+		lgr.Error().
+			Stack().
+			Int("HTTP Error StatusCode", status).
+			Msg("nil error - no response body sent")
+		return
+	}
+
+	if m, kind, ok := multiErrorOf(err); ok {
+		lgr.WithLevel(h.logLevel(kind)).
+			Stack().
+			Err(redacted(err)).
+			Str("kind", kind.String()).
+			Int("errors", len(m)).
+			Msg("multiple errors")
+		return
+	}
+
+	var e *Error
+	if !errors.As(err, &e) {
+		lgr.Error().Stack().Err(redacted(err)).Int("code", status).Msg("unknown error")
+		return
+	}
+
+	kind := KindOf(err)
+	level := h.logLevel(kind)
+	switch kind {
+	case Validation:
+		verr, ok := validationErrorsOf(err)
+		if !ok {
+			lgr.Error().Stack().Msg("validation error not having appropriate error")
+			return
+		}
+
+		lgr.WithLevel(level).
+			Stack().
+			Err(e).
+			Int("fields", len(verr)).
+			Msg("input validation error")
+	case Unauthenticated:
+		lgr.WithLevel(level).
+			Stack().
+			Err(e).
+			Msg("unauthenticated request")
+	case Unauthorized:
+		lgr.WithLevel(level).
+			Stack().
+			Err(e).
+			Msg("unauthorized request")
+	default:
+		if e.isZero() {
+			lgr.WithLevel(level).
+				Stack().
+				Str("kind", kind.String()).
+				Msg(DefaultRedactor.Message(e.Error()))
+			return
+		}
+
+		lgr.WithLevel(level).
+			Stack().
+			Err(e).
+			Msg("common error")
+	}
+}
+
+func opStrings(ops []Op) []string {
+	out := make([]string, len(ops))
+	for i, op := range ops {
+		out[i] = string(op)
+	}
+
+	return out
+}
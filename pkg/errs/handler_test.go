@@ -0,0 +1,192 @@
+package errs_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	l := zerolog.New(os.Stdout).Level(zerolog.DebugLevel)
+
+	t.Run("default behaves as HTTPErrorHandler", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		errs.NewHTTPHandler().Handle(w, nil, l, errs.E(errs.NotExist, errs.Code("product_not_exist"), "not exist"))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, `{"error":{"kind":"resource_does_not_exist","code":"product_not_exist","message":"not exist"}}`, w.Body.String())
+	})
+
+	t.Run("kind behind a wrap", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		errs.NewHTTPHandler().Handle(w, nil, l, errs.E(errs.Op("user.Get"), fmt.Errorf("repo: %w", errs.E(errs.NotExist, "x"))))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), `"kind":"resource_does_not_exist"`)
+
+		w = httptest.NewRecorder()
+		errs.NewHTTPHandler().Handle(w, nil, l, errs.E(errs.Op("user.Create"), fmt.Errorf("validate: %w", errs.E(errs.Validation, errs.ValidationErrors{
+			errs.E(errs.Parameter("email"), "email is required"),
+		}))))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, `{"errors":[{"param":"email","pointer":"/email","message":"email is required"}]}`, w.Body.String())
+
+		w = httptest.NewRecorder()
+		errs.NewHTTPHandler().Handle(w, nil, l, errs.E(errs.Op("user.Get"), fmt.Errorf("auth: %w", errs.E(errs.Unauthenticated, errs.Realm("users"), "token expired"))))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, `Bearer realm="users"`, w.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("custom status mapper", func(t *testing.T) {
+		h := errs.NewHTTPHandler(errs.WithStatusMapper(func(k errs.Kind) int {
+			if k == errs.Invalid {
+				return http.StatusUnprocessableEntity
+			}
+			return errs.HTTPStatusCodeFromKind(k)
+		}))
+
+		w := httptest.NewRecorder()
+		h.Handle(w, nil, l, errs.E(errs.Invalid, "invalid operation"))
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		w = httptest.NewRecorder()
+		h.Handle(w, nil, l, errs.E(errs.NotExist, "not exist"))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("custom hidden kinds", func(t *testing.T) {
+		h := errs.NewHTTPHandler(errs.WithHiddenKinds(errs.Private))
+
+		w := httptest.NewRecorder()
+		h.Handle(w, nil, l, errs.E(errs.Private, "secret"))
+		assert.Equal(t, `{"error":{"kind":"private","message":"internal server error"}}`, w.Body.String())
+
+		w = httptest.NewRecorder()
+		h.Handle(w, nil, l, errs.E(errs.IO, "connection refused"))
+		assert.Equal(t, `{"error":{"kind":"I/O_error","message":"connection refused"}}`, w.Body.String())
+	})
+
+	t.Run("custom unknown status", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		errs.NewHTTPHandler(errs.WithUnknownStatus(http.StatusInternalServerError)).Handle(w, nil, l, fmt.Errorf("unknown"))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("custom log hook", func(t *testing.T) {
+		var (
+			gotStatus int
+			gotErr    error
+		)
+
+		h := errs.NewHTTPHandler(errs.WithLogHook(func(_ zerolog.Logger, status int, err error) {
+			gotStatus = status
+			gotErr = err
+		}))
+
+		err := errs.E(errs.Exist, "already exist")
+		h.Handle(httptest.NewRecorder(), nil, l, err)
+		assert.Equal(t, http.StatusConflict, gotStatus)
+		assert.Equal(t, err, gotErr)
+	})
+}
+
+func TestHTTPHandler_LogFields(t *testing.T) {
+	var buff bytes.Buffer
+	l := zerolog.New(&buff)
+
+	err := errs.E(errs.Op("product.Get"), errs.Field("request_id", "req-1"), errs.E(errs.Op("db.Get"), errs.NotExist, "not exist"))
+	errs.NewHTTPHandler().Handle(httptest.NewRecorder(), nil, l, err)
+
+	assert.Contains(t, buff.String(), `"request_id":"req-1"`)
+	assert.Contains(t, buff.String(), `"ops":["product.Get","db.Get"]`)
+}
+
+func TestHTTPHandler_PublicMessage(t *testing.T) {
+	l := zerolog.New(os.Stdout).Level(zerolog.DebugLevel)
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"common error", errs.E(errs.Exist, errs.Message("email already taken"), fmt.Errorf("pq: duplicate key value violates unique constraint")),
+			`{"error":{"kind":"resource_already_exists","message":"email already taken"}}`},
+		{"pulled up from inner error", errs.E(errs.Op("user.Create"), errs.E(errs.Exist, errs.Message("email already taken"), "duplicate key")),
+			`{"error":{"kind":"resource_already_exists","message":"email already taken"}}`},
+		{"hidden kind", errs.E(errs.Database, errs.Message("please try again later"), "connection reset by peer"),
+			`{"error":{"kind":"database_error","message":"please try again later"}}`},
+		{"validation", errs.E(errs.Validation, errs.ValidationErrors{
+			errs.E(errs.Parameter("email"), errs.Message("email is invalid"), "mail: missing '@' or angle-addr"),
+		}), `{"errors":[{"param":"email","pointer":"/email","message":"email is invalid"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			errs.NewHTTPHandler().Handle(w, nil, l, tt.err)
+			assert.Equal(t, tt.want, w.Body.String())
+		})
+	}
+}
+
+func TestHTTPHandler_NoOpsInBody(t *testing.T) {
+	l := zerolog.New(io.Discard)
+
+	tests := []struct {
+		name string
+		err  error
+		ops  []string
+		want string
+	}{
+		{
+			name: "common error",
+			err:  errs.E(errs.Op("user.Create"), errs.E(errs.Op("db.Insert"), errs.Exist, "duplicate key")),
+			ops:  []string{"user.Create", "db.Insert"},
+			want: `{"error":{"kind":"resource_already_exists","message":"duplicate key"}}`,
+		},
+		{
+			name: "validation errors",
+			err: errs.E(errs.Op("user.Create"), errs.Validation, errs.ValidationErrors{
+				errs.E(errs.Op("user.validate"), errs.Parameter("email"), "email is required"),
+			}),
+			ops:  []string{"user.Create", "user.validate"},
+			want: `{"errors":[{"param":"email","pointer":"/email","message":"email is required"}]}`,
+		},
+		{
+			name: "op behind a wrap",
+			err:  errs.E(errs.Op("user.Get"), fmt.Errorf("repo: %w", errs.E(errs.Op("db.Get"), errs.NotExist, "x"))),
+			ops:  []string{"user.Get", "db.Get"},
+			want: `{"error":{"kind":"resource_does_not_exist","message":"x"}}`,
+		},
+		{
+			name: "multiple errors",
+			err:  errs.E(errs.Op("user.Import"), errs.Join(errs.E(errs.Op("db.Insert"), errs.Exist, "duplicate key"))),
+			ops:  []string{"user.Import", "db.Insert"},
+			want: `{"errors":[{"kind":"resource_already_exists","message":"duplicate key"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, rd := range []errs.Renderer{errs.JSONRenderer{}, errs.ProblemRenderer{}, errs.XMLRenderer{}, errs.TextRenderer{}, errs.HTMLRenderer{}} {
+				w := httptest.NewRecorder()
+				errs.NewHTTPHandler(errs.WithRenderer(rd)).Handle(w, nil, l, tt.err)
+
+				for _, op := range tt.ops {
+					assert.NotContains(t, w.Body.String(), op, "%T", rd)
+				}
+			}
+
+			w := httptest.NewRecorder()
+			errs.NewHTTPHandler().Handle(w, nil, l, tt.err)
+			assert.Equal(t, tt.want, w.Body.String())
+		})
+	}
+}
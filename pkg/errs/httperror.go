@@ -2,7 +2,6 @@ package errs
 
 import (
 	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -23,7 +22,7 @@ func (v ValidationErrors) Error() string {
 	for i := 0; i < len(v); i++ {
 
 		if err, ok := v[i].(*Error); ok {
-			buff.WriteString(fmt.Sprintf("%s: %s", err.Param, err.Error()))
+			buff.WriteString(fmt.Sprintf("%s: %s", err.Param, err.Detail()))
 			buff.WriteString("\n")
 		}
 	}
@@ -31,180 +30,70 @@ func (v ValidationErrors) Error() string {
 	return strings.TrimSpace(buff.String())
 }
 
-// HTTPErrResponse is used as the Response Body
-type HTTPErrResponse struct {
-	Error  *ServiceError  `json:"error,omitempty"`
-	Errors []ServiceError `json:"errors,omitempty"`
-}
-
-// ServiceError has fields for Service errors. All fields with no data will
-// be omitted
-type ServiceError struct {
-	Kind    string `json:"kind,omitempty"`
-	Code    string `json:"code,omitempty"`
-	Param   string `json:"param,omitempty"`
-	Message string `json:"message,omitempty"`
-}
-
-// HTTPErrorHandler is a pre-defined http error handler, it will translate given error structured response
-// it also support to log given error
-func HTTPErrorHandler(w http.ResponseWriter, lgr zerolog.Logger, err error) {
-	if err == nil {
-		lgr.Error().
-			Stack().
-			Int("HTTP Error StatusCode", http.StatusInternalServerError).
-			Msg("nil error - no response body sent")
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	var e *Error
-	if errors.As(err, &e) {
-		switch e.Kind {
-		case Validation:
-			validationErrHandler(w, lgr, e)
-			return
-		case Unauthenticated:
-			unauthenticatedErrHandler(w, lgr, e)
-			return
-		case Unauthorized:
-			unauthorizedErrHandler(w, lgr, e)
-			return
-		default:
-			commonErrHandler(w, lgr, e)
-			return
+// ByParam groups the errors by their parameter, keeping the order they were appended in.
+// A parameter may have multiple errors, and the errors that are not an *Error are grouped
+// under the empty parameter.
+func (v ValidationErrors) ByParam() map[Parameter][]error {
+	out := make(map[Parameter][]error)
+	for _, err := range v {
+		var param Parameter
+		if e, ok := err.(*Error); ok {
+			param = e.Param
 		}
+		out[param] = append(out[param], err)
 	}
 
-	unknownErrHandler(w, lgr, err)
+	return out
 }
 
-func commonErrHandler(w http.ResponseWriter, lgr zerolog.Logger, e *Error) {
-	if e.isZero() {
-		lgr.Error().
-			Stack().
-			Str("kind", e.Kind.String()).
-			Msg(e.Error())
-
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	lgr.Error().
-		Stack().
-		Err(e.Err).
-		Str("kind", e.Kind.String()).
-		Str("username", string(e.User)).
-		Str("parameter", string(e.Param)).
-		Str("code", string(e.Code)).
-		Msg("common error")
-
-	var errResponse HTTPErrResponse
-	switch e.Kind {
-	case Internal, Database, IO:
-		errResponse = HTTPErrResponse{
-			Error: &ServiceError{
-				Kind:    e.Kind.String(),
-				Message: "internal server error",
-			},
-		}
-	default:
-		errResponse = HTTPErrResponse{
-			Error: &ServiceError{
-				Kind:    e.Kind.String(),
-				Code:    string(e.Code),
-				Param:   string(e.Param),
-				Message: e.Error(),
-			},
-		}
-	}
+// Params returns the distinct parameters of the errors, in the order they were appended in
+func (v ValidationErrors) Params() []Parameter {
+	seen := make(map[Parameter]bool)
 
-	errJSON, _ := json.Marshal(errResponse)
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.WriteHeader(HTTPStatusCodeFromError(e))
-	w.Write(errJSON)
-}
-
-func validationErrHandler(w http.ResponseWriter, lgr zerolog.Logger, e *Error) {
-	verr, ok := e.Err.(ValidationErrors)
-	if !ok {
-		lgr.Error().Stack().Msg("validation error not having appropriate error")
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	lgr.Error().
-		Stack().
-		Err(e.Err).
-		Int("fields", len(verr)).
-		Msg("input validation error")
-
-	var errs []ServiceError
-	for _, err := range verr {
-		ie, ok := err.(*Error)
-		if !ok {
-			lgr.Error().
-				Stack().
-				Err(err).
-				Msg("input validation error - unexpected error")
+	var params []Parameter
+	for _, err := range v {
+		e, ok := err.(*Error)
+		if !ok || seen[e.Param] {
 			continue
 		}
 
-		errs = append(errs, ServiceError{
-			Code:    string(ie.Code),
-			Param:   string(ie.Param),
-			Message: ie.Error(),
-		})
+		seen[e.Param] = true
+		params = append(params, e.Param)
 	}
 
-	errJSON, _ := json.Marshal(HTTPErrResponse{
-		Errors: errs,
-	})
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.WriteHeader(HTTPStatusCodeFromError(e))
-	w.Write(errJSON)
+	return params
 }
 
-func unauthenticatedErrHandler(w http.ResponseWriter, lgr zerolog.Logger, e *Error) {
-	lgr.Error().
-		Stack().
-		Err(e.Err).
-		Str("realm", string(e.Realm)).
-		Str("user", string(e.User)).
-		Msg("unauthenticated request")
-
-	w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s"`, e.Realm))
-	w.WriteHeader(HTTPStatusCodeFromError(e))
+// HTTPErrResponse is used as the Response Body
+type HTTPErrResponse struct {
+	Error  *ServiceError  `json:"error,omitempty"`
+	Errors []ServiceError `json:"errors,omitempty"`
 }
 
-func unauthorizedErrHandler(w http.ResponseWriter, lgr zerolog.Logger, e *Error) {
-	lgr.Error().
-		Stack().
-		Err(e.Err).
-		Str("realm", string(e.Realm)).
-		Str("user", string(e.User)).
-		Msg("unauthorized request")
-
-	w.WriteHeader(HTTPStatusCodeFromError(e))
+// ServiceError has fields for Service errors. All fields with no data will
+// be omitted. The Pointer is the RFC 6901 JSON Pointer of the Param, see Parameter.Pointer.
+type ServiceError struct {
+	Kind    string `json:"kind,omitempty" xml:"kind,omitempty"`
+	Code    string `json:"code,omitempty" xml:"code,omitempty"`
+	Param   string `json:"param,omitempty" xml:"param,omitempty"`
+	Pointer string `json:"pointer,omitempty" xml:"pointer,omitempty"`
+	Message string `json:"message,omitempty" xml:"message,omitempty"`
+	DocsURL string `json:"docs_url,omitempty" xml:"docs_url,omitempty"`
 }
 
-func unknownErrHandler(w http.ResponseWriter, lgr zerolog.Logger, err error) {
-	errResponse := HTTPErrResponse{
-		Error: &ServiceError{
-			Code:    "unknown_error",
-			Message: "unknown error - please contact support",
-		},
-	}
-
-	lgr.Error().Stack().Err(err).Int("code", HTTPStatusCodeFromError(err)).Msg("unknown error")
+// HTTPErrorHandler is a pre-defined http error handler, it will translate given error structured response
+// it also support to log given error
+//
+// Deprecated: without the request, neither the response body is negotiated against the Accept header
+// nor the message localized, use HTTPRequestErrorHandler instead.
+func HTTPErrorHandler(w http.ResponseWriter, lgr zerolog.Logger, err error, opts ...HandlerOption) {
+	NewHTTPHandler(opts...).Handle(w, nil, lgr, err)
+}
 
-	errJSON, _ := json.Marshal(errResponse)
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.WriteHeader(http.StatusNotImplemented)
-	w.Write(errJSON)
+// HTTPRequestErrorHandler is a pre-defined http error handler, it will translate given error structured response
+// negotiated against the Accept header of given request, see WithRenderers, it also support to log given error
+func HTTPRequestErrorHandler(w http.ResponseWriter, r *http.Request, lgr zerolog.Logger, err error, opts ...HandlerOption) {
+	NewHTTPHandler(opts...).Handle(w, r, lgr, err)
 }
 
 // HTTPStatusCodeFromError translate error to an http status code
@@ -215,22 +104,14 @@ func HTTPStatusCodeFromError(err error) int {
 		return http.StatusNotImplemented
 	}
 
-	switch e.Kind {
-	case Validation:
-		return http.StatusBadRequest
-	case NotExist:
-		return http.StatusNotFound
-	case Invalid, InvalidRequest:
-		return http.StatusNotAcceptable
-	case Exist:
-		return http.StatusConflict
-	case Unauthenticated:
-		return http.StatusUnauthorized
-	case Unauthorized:
-		return http.StatusForbidden
-	case Other, IO, Internal, Private, Database:
-		return http.StatusInternalServerError
-	default:
-		return http.StatusInternalServerError
+	return HTTPStatusCodeFromKind(KindOf(err))
+}
+
+// HTTPStatusCodeFromKind translate error kind to an http status code
+func HTTPStatusCodeFromKind(k Kind) int {
+	if info, ok := LookupKind(k); ok {
+		return info.HTTPStatus
 	}
+
+	return http.StatusInternalServerError
 }
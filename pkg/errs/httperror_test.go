@@ -42,6 +42,11 @@ func TestHTTPStatusCodeFromError(t *testing.T) {
 		})
 	}
 
+	t.Run("kind behind a wrap", func(t *testing.T) {
+		got := errs.HTTPStatusCodeFromError(errs.E(errs.Op("user.Get"), fmt.Errorf("repo: %w", errs.E(errs.NotExist, "x"))))
+		assert.Equal(t, http.StatusNotFound, got)
+	})
+
 	t.Run("Unknown", func(t *testing.T) {
 		got := errs.HTTPStatusCodeFromError(fmt.Errorf("unknown error"))
 		assert.Equal(t, http.StatusNotImplemented, got)
@@ -162,7 +167,7 @@ func TestHTTPErrorHandler_Body(t *testing.T) {
 				errs.E(errs.Parameter("key"), "bad format"),
 				errs.E(errs.Parameter("last_name"), "bad format"),
 			}),
-		}, `{"errors":[{"param":"key","message":"bad format"},{"param":"last_name","message":"bad format"}]}`},
+		}, `{"errors":[{"param":"key","pointer":"/key","message":"bad format"},{"param":"last_name","pointer":"/last_name","message":"bad format"}]}`},
 		{"Validation with unexpected error", args{
 			w: httptest.NewRecorder(),
 			l: l,
@@ -170,7 +175,7 @@ func TestHTTPErrorHandler_Body(t *testing.T) {
 				fmt.Errorf("unexpected error"),
 				errs.E(errs.Parameter("key"), "bad format"),
 			}),
-		}, `{"errors":[{"param":"key","message":"bad format"}]}`},
+		}, `{"errors":[{"param":"key","pointer":"/key","message":"bad format"}]}`},
 		{"Unauthenticated", args{
 			w:   httptest.NewRecorder(),
 			l:   l,
@@ -201,3 +206,19 @@ func TestHTTPErrorHandler_Body(t *testing.T) {
 		})
 	}
 }
+
+func TestValidationErrors_ByParam(t *testing.T) {
+	var verr errs.ValidationErrors
+	verr.Append(errs.Parameter("email"), errs.Code("required"), "email is required")
+	verr.Append(errs.Path("items", 2, "sku"), "sku is required")
+	verr.Append(errs.Parameter("email"), errs.Code("bad_format"), "email is malformed")
+	verr = append(verr, fmt.Errorf("malformed body"))
+
+	groups := verr.ByParam()
+	assert.Len(t, groups, 3)
+	assert.Equal(t, []error{verr[0], verr[2]}, groups["email"])
+	assert.Equal(t, []error{verr[1]}, groups["items[2].sku"])
+	assert.Equal(t, []error{verr[3]}, groups[""])
+
+	assert.Equal(t, []errs.Parameter{"email", "items[2].sku"}, verr.Params())
+}
@@ -0,0 +1,197 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// JSONIncludeUser reports whether the User is encoded by Error.MarshalJSON.
+// It is disabled by default, since the username is often a personal data.
+var JSONIncludeUser = false
+
+// jsonError is the JSON representation of an *Error
+type jsonError struct {
+	Op    Op        `json:"op,omitempty"`
+	Kind  string    `json:"kind,omitempty"`
+	Code  Code      `json:"code,omitempty"`
+	Param Parameter `json:"param,omitempty"`
+	User  UserName  `json:"user,omitempty"`
+	Realm Realm     `json:"realm,omitempty"`
+
+	// Fields is the structured metadata, numbers are decoded as float64
+	Fields Fields `json:"fields,omitempty"`
+
+	// Retryable is the Retry explicitly set, if any
+	Retryable *bool `json:"retryable,omitempty"`
+
+	// RetryAfter is the RetryAfter hint, as a duration string
+	RetryAfter string `json:"retry_after,omitempty"`
+
+	// PublicMessage is the user-facing Message
+	PublicMessage Message `json:"public_message,omitempty"`
+
+	// Message is the message of the underlying error, when it is not an *Error
+	Message string `json:"message,omitempty"`
+
+	// Err is the underlying *Error, if any
+	Err *jsonError `json:"err,omitempty"`
+
+	// Errors are the underlying ValidationErrors, if any
+	Errors []*jsonError `json:"errors,omitempty"`
+
+	// Joined are the members of the underlying MultiError, if any
+	Joined []*jsonError `json:"joined,omitempty"`
+}
+
+// MarshalJSON encodes the error, along with the chain of the underlying errors.
+// Only the message is kept from an underlying error that is not an *Error.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSONError(e))
+}
+
+// UnmarshalJSON decodes the error encoded by MarshalJSON.
+// The underlying errors that were not an *Error are reconstructed with their message only.
+// A kind that is not registered decodes as Other, keeping its name in the "kind" field.
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var je jsonError
+	if err := json.Unmarshal(data, &je); err != nil {
+		return err
+	}
+
+	out, err := fromJSONError(&je)
+	if err != nil {
+		return err
+	}
+
+	*e = *out
+	return nil
+}
+
+func toJSONError(err error) *jsonError {
+	e, ok := err.(*Error)
+	if !ok {
+		return &jsonError{Message: err.Error()}
+	}
+
+	je := &jsonError{
+		Op:    e.Op,
+		Code:  e.Code,
+		Param: e.Param,
+		Realm: e.Realm,
+
+		Fields: e.Fields,
+
+		PublicMessage: e.Message,
+	}
+
+	if e.Kind != Other {
+		je.Kind = e.Kind.String()
+	}
+
+	if JSONIncludeUser {
+		je.User = e.User
+	}
+
+	if e.Retry != RetryUnset {
+		retryable := e.Retry == Retryable
+		je.Retryable = &retryable
+	}
+
+	if e.RetryAfter > 0 {
+		je.RetryAfter = time.Duration(e.RetryAfter).String()
+	}
+
+	switch inner := e.Err.(type) {
+	case nil:
+	case *Error:
+		je.Err = toJSONError(inner)
+	case ValidationErrors:
+		for _, ve := range inner {
+			je.Errors = append(je.Errors, toJSONError(ve))
+		}
+	case MultiError:
+		for _, me := range inner {
+			je.Joined = append(je.Joined, toJSONError(me))
+		}
+	default:
+		if !errors.Is(inner, ErrUndefined) {
+			je.Message = inner.Error()
+		}
+	}
+
+	return je
+}
+
+func fromJSONError(je *jsonError) (*Error, error) {
+	e := &Error{
+		Op:    je.Op,
+		Code:  je.Code,
+		Param: je.Param,
+		User:  je.User,
+		Realm: je.Realm,
+
+		Fields: je.Fields,
+
+		Message: je.PublicMessage,
+	}
+
+	if je.Retryable != nil {
+		e.Retry = NotRetryable
+		if *je.Retryable {
+			e.Retry = Retryable
+		}
+	}
+
+	if je.RetryAfter != "" {
+		d, err := time.ParseDuration(je.RetryAfter)
+		if err != nil {
+			return nil, err
+		}
+		e.RetryAfter = RetryAfter(d)
+	}
+
+	if je.Kind != "" {
+		if kind, err := ParseKind(je.Kind); err == nil {
+			e.Kind = kind
+		} else {
+			// The kind is not registered by this service, keep its name for diagnostics.
+			e.Fields = mergeFields(e.Fields, Field("kind", je.Kind))
+		}
+	}
+
+	switch {
+	case je.Err != nil:
+		inner, err := fromJSONError(je.Err)
+		if err != nil {
+			return nil, err
+		}
+		e.Err = inner
+	case len(je.Errors) > 0:
+		verr := make(ValidationErrors, 0, len(je.Errors))
+		for _, ije := range je.Errors {
+			inner, err := fromJSONError(ije)
+			if err != nil {
+				return nil, err
+			}
+			verr = append(verr, inner)
+		}
+		e.Err = verr
+	case len(je.Joined) > 0:
+		m := make(MultiError, 0, len(je.Joined))
+		for _, ije := range je.Joined {
+			inner, err := fromJSONError(ije)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, inner)
+		}
+		e.Err = m
+	case je.Message != "":
+		e.Err = errors.New(je.Message)
+	default:
+		e.Err = ErrUndefined
+	}
+
+	return e, nil
+}
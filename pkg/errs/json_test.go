@@ -0,0 +1,98 @@
+package errs_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			"common error",
+			errs.E(errs.NotExist, errs.Code("product_not_exist"), errs.Parameter("id"), "product not exist"),
+			`{"kind":"resource_does_not_exist","code":"product_not_exist","param":"id","message":"product not exist"}`,
+		},
+		{
+			"nested error",
+			errs.E(errs.Code("outer"), errs.E(errs.Database, errs.Parameter("id"), fmt.Errorf("duplicate key"))),
+			`{"kind":"database_error","code":"outer","param":"id","err":{"message":"duplicate key"}}`,
+		},
+		{
+			"validation error",
+			errs.E(errs.Validation, errs.ValidationErrors{
+				errs.E(errs.Parameter("key"), errs.Code("bad_format"), "bad format"),
+			}),
+			`{"kind":"input_validation_error","errors":[{"code":"bad_format","param":"key","message":"bad format"}]}`,
+		},
+		{
+			"multiple errors",
+			errs.E(errs.Op("cart.Checkout"), errs.Join(
+				errs.E(errs.NotExist, errs.Parameter("items[0]"), "product 14 not exist"),
+				errs.E(errs.Exist, errs.Parameter("items[1]"), "product 15 already exist"),
+			)),
+			`{"op":"cart.Checkout","kind":"resource_already_exists","joined":[{"kind":"resource_does_not_exist","param":"items[0]","message":"product 14 not exist"},{"kind":"resource_already_exists","param":"items[1]","message":"product 15 already exist"}]}`,
+		},
+		{
+			"undefined error",
+			errs.E(errs.Unauthenticated),
+			`{"kind":"unauthenticated_request","realm":"restricted"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.err)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(data))
+
+			var got errs.Error
+			require.NoError(t, json.Unmarshal(data, &got))
+			assert.True(t, errs.Match(tt.err, &got), "round trip mismatched: %s", data)
+			assert.Equal(t, tt.err.Error(), got.Error())
+		})
+	}
+
+	t.Run("multiple errors members", func(t *testing.T) {
+		var got errs.Error
+		require.NoError(t, json.Unmarshal([]byte(`{"kind":"resource_already_exists","joined":[{"kind":"resource_does_not_exist","message":"a"},{"message":"b"}]}`), &got))
+
+		m, ok := got.Err.(errs.MultiError)
+		require.True(t, ok, "%T", got.Err)
+		require.Len(t, m, 2)
+		assert.True(t, errs.KindIs(errs.NotExist, m[0]))
+		assert.Equal(t, "b", m[1].Error())
+	})
+
+	t.Run("include user", func(t *testing.T) {
+		defer func(v bool) { errs.JSONIncludeUser = v }(errs.JSONIncludeUser)
+		errs.JSONIncludeUser = true
+
+		err := errs.E(errs.Unauthorized, errs.UserName("john@doe.com"), "unauthorized access")
+		data, jerr := json.Marshal(err)
+		require.NoError(t, jerr)
+		assert.Equal(t, `{"kind":"unauthorized_request","user":"john@doe.com","message":"unauthorized access"}`, string(data))
+
+		var got errs.Error
+		require.NoError(t, json.Unmarshal(data, &got))
+		assert.True(t, errs.Match(err, &got))
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		var got errs.Error
+		require.NoError(t, json.Unmarshal([]byte(`{"kind":"what","code":"quota_exceeded","message":"quota exceeded"}`), &got))
+
+		assert.Equal(t, errs.Other, got.Kind)
+		assert.Equal(t, errs.Code("quota_exceeded"), got.Code)
+		assert.Equal(t, "quota exceeded", got.Error())
+		assert.Equal(t, errs.Fields{"kind": "what"}, got.Fields)
+	})
+}
@@ -0,0 +1,138 @@
+package errs
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// KindInfo describes an error Kind
+type KindInfo struct {
+	// Name is the name of the kind, as returned by Kind.String
+	Name string
+
+	// HTTPStatus is the HTTP status code responded for the kind
+	HTTPStatus int
+
+	// GRPCCode is the value of the google.golang.org/grpc/codes.Code for the kind
+	GRPCCode uint32
+
+	// Retryable tells whether an error of the kind is worth retrying, see IsRetryable
+	Retryable bool
+}
+
+// grpcCodeUnknown is the value of codes.Unknown
+const grpcCodeUnknown = 2
+
+// firstCustomKind is the first Kind allocated by RegisterKind,
+// the kinds below are reserved for the built-in ones
+const firstCustomKind Kind = 64
+
+var kindRegistry = struct {
+	sync.RWMutex
+	kinds map[Kind]KindInfo
+	names map[string]Kind
+	next  Kind
+}{
+	kinds: map[Kind]KindInfo{
+		Other:              {Name: "other_error", HTTPStatus: http.StatusInternalServerError, GRPCCode: 2},                          // codes.Unknown
+		IO:                 {Name: "I/O_error", HTTPStatus: http.StatusInternalServerError, GRPCCode: 14, Retryable: true},          // codes.Unavailable
+		Private:            {Name: "private", HTTPStatus: http.StatusInternalServerError, GRPCCode: 7},                              // codes.PermissionDenied
+		Internal:           {Name: "internal_error", HTTPStatus: http.StatusInternalServerError, GRPCCode: 13},                      // codes.Internal
+		Database:           {Name: "database_error", HTTPStatus: http.StatusInternalServerError, GRPCCode: 13},                      // codes.Internal
+		Exist:              {Name: "resource_already_exists", HTTPStatus: http.StatusConflict, GRPCCode: 6},                         // codes.AlreadyExists
+		NotExist:           {Name: "resource_does_not_exist", HTTPStatus: http.StatusNotFound, GRPCCode: 5},                         // codes.NotFound
+		Invalid:            {Name: "invalid_operation", HTTPStatus: http.StatusNotAcceptable, GRPCCode: 9},                          // codes.FailedPrecondition
+		Validation:         {Name: "input_validation_error", HTTPStatus: http.StatusBadRequest, GRPCCode: 3},                        // codes.InvalidArgument
+		InvalidRequest:     {Name: "invalid_request_error", HTTPStatus: http.StatusNotAcceptable, GRPCCode: 3},                      // codes.InvalidArgument
+		Unauthenticated:    {Name: "unauthenticated_request", HTTPStatus: http.StatusUnauthorized, GRPCCode: 16},                    // codes.Unauthenticated
+		Unauthorized:       {Name: "unauthorized_request", HTTPStatus: http.StatusForbidden, GRPCCode: 7},                           // codes.PermissionDenied
+		RateLimited:        {Name: "rate_limited", HTTPStatus: http.StatusTooManyRequests, GRPCCode: 8, Retryable: true},            // codes.ResourceExhausted
+		Conflict:           {Name: "conflict", HTTPStatus: http.StatusConflict, GRPCCode: 10},                                       // codes.Aborted
+		Timeout:            {Name: "timeout", HTTPStatus: http.StatusGatewayTimeout, GRPCCode: 4, Retryable: true},                  // codes.DeadlineExceeded
+		Unavailable:        {Name: "service_unavailable", HTTPStatus: http.StatusServiceUnavailable, GRPCCode: 14, Retryable: true}, // codes.Unavailable
+		PreconditionFailed: {Name: "precondition_failed", HTTPStatus: http.StatusPreconditionFailed, GRPCCode: 9},                   // codes.FailedPrecondition
+		PaymentRequired:    {Name: "payment_required", HTTPStatus: http.StatusPaymentRequired, GRPCCode: 9},                         // codes.FailedPrecondition
+	},
+	next: firstCustomKind,
+}
+
+func init() {
+	kindRegistry.names = make(map[string]Kind, len(kindRegistry.kinds))
+	for k, info := range kindRegistry.kinds {
+		kindRegistry.names[info.Name] = k
+	}
+}
+
+// RegisterKind registers an application-specific kind, and returns it.
+// As such it is meant to be called once, at initialization:
+//
+//	var QuotaExceeded = errs.RegisterKind(errs.KindInfo{
+//		Name:       "quota_exceeded",
+//		HTTPStatus: http.StatusForbidden,
+//		GRPCCode:   8, // codes.ResourceExhausted
+//	})
+//
+// If HTTPStatus is left unset, it is set to http.StatusInternalServerError,
+// and if GRPCCode is left unset, it is set to 2, codes.Unknown, as 0 is codes.OK.
+// RegisterKind panics if the name is empty or already registered,
+// or when no more kind can be allocated.
+func RegisterKind(info KindInfo) Kind {
+	if info.Name == "" {
+		panic("errs.RegisterKind: empty name")
+	}
+
+	if info.HTTPStatus == 0 {
+		info.HTTPStatus = http.StatusInternalServerError
+	}
+
+	if info.GRPCCode == 0 {
+		info.GRPCCode = grpcCodeUnknown
+	}
+
+	kindRegistry.Lock()
+	defer kindRegistry.Unlock()
+
+	if _, exist := kindRegistry.names[info.Name]; exist {
+		panic(fmt.Sprintf("errs.RegisterKind: duplicate kind %q", info.Name))
+	}
+
+	k := kindRegistry.next
+	if k < firstCustomKind {
+		panic("errs.RegisterKind: too many kinds")
+	}
+	kindRegistry.next++
+
+	kindRegistry.kinds[k] = info
+	kindRegistry.names[info.Name] = k
+	return k
+}
+
+// LookupKind returns the info of a built-in or registered kind
+func LookupKind(k Kind) (KindInfo, bool) {
+	kindRegistry.RLock()
+	defer kindRegistry.RUnlock()
+
+	info, ok := kindRegistry.kinds[k]
+	return info, ok
+}
+
+func (k Kind) String() string {
+	if info, ok := LookupKind(k); ok {
+		return info.Name
+	}
+
+	return "unknown_error"
+}
+
+// ParseKind returns the Kind represented by the given name, as returned by Kind.String.
+func ParseKind(name string) (Kind, error) {
+	kindRegistry.RLock()
+	defer kindRegistry.RUnlock()
+
+	if k, ok := kindRegistry.names[name]; ok {
+		return k, nil
+	}
+
+	return Other, fmt.Errorf("errs: unknown kind %q", name)
+}
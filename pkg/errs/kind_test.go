@@ -0,0 +1,83 @@
+package errs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+var kindTestQuotaExceeded = errs.RegisterKind(errs.KindInfo{
+	Name:       "test_quota_exceeded",
+	HTTPStatus: http.StatusForbidden,
+	GRPCCode:   8,
+})
+
+func TestBuiltinKinds(t *testing.T) {
+	tests := []struct {
+		kind   errs.Kind
+		name   string
+		status int
+	}{
+		{errs.RateLimited, "rate_limited", http.StatusTooManyRequests},
+		{errs.Conflict, "conflict", http.StatusConflict},
+		{errs.Timeout, "timeout", http.StatusGatewayTimeout},
+		{errs.Unavailable, "service_unavailable", http.StatusServiceUnavailable},
+		{errs.PreconditionFailed, "precondition_failed", http.StatusPreconditionFailed},
+		{errs.PaymentRequired, "payment_required", http.StatusPaymentRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.name, tt.kind.String())
+			assert.Equal(t, tt.status, errs.HTTPStatusCodeFromError(errs.E(tt.kind)))
+
+			k, err := errs.ParseKind(tt.name)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.kind, k)
+		})
+	}
+}
+
+func TestRegisterKind(t *testing.T) {
+	t.Run("registered kind", func(t *testing.T) {
+		assert.Equal(t, "test_quota_exceeded", kindTestQuotaExceeded.String())
+		assert.Equal(t, http.StatusForbidden, errs.HTTPStatusCodeFromKind(kindTestQuotaExceeded))
+
+		k, err := errs.ParseKind("test_quota_exceeded")
+		assert.NoError(t, err)
+		assert.Equal(t, kindTestQuotaExceeded, k)
+
+		info, ok := errs.LookupKind(kindTestQuotaExceeded)
+		assert.True(t, ok)
+		assert.Equal(t, uint32(8), info.GRPCCode)
+	})
+
+	t.Run("default status", func(t *testing.T) {
+		k := errs.RegisterKind(errs.KindInfo{Name: "test_default_status"})
+		assert.Equal(t, http.StatusInternalServerError, errs.HTTPStatusCodeFromKind(k))
+
+		info, _ := errs.LookupKind(k)
+		assert.Equal(t, uint32(2), info.GRPCCode, "codes.Unknown")
+	})
+
+	t.Run("rendered by the handler", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		errs.HTTPErrorHandler(w, zerolog.New(os.Stdout), errs.E(kindTestQuotaExceeded, "quota exceeded"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, `{"error":{"kind":"test_quota_exceeded","message":"quota exceeded"}}`, w.Body.String())
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		assert.Panics(t, func() { errs.RegisterKind(errs.KindInfo{Name: "test_quota_exceeded"}) })
+		assert.Panics(t, func() { errs.RegisterKind(errs.KindInfo{Name: errs.NotExist.String()}) })
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		assert.Panics(t, func() { errs.RegisterKind(errs.KindInfo{}) })
+	})
+}
@@ -0,0 +1,163 @@
+package errs
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// WithLogLevels sets the level which the errors of given kinds are logged at by the default LogHook,
+// such as zerolog.InfoLevel for Validation and NotExist. The errors of other kinds, and errors
+// that are not an *Error, are logged at zerolog.ErrorLevel.
+func WithLogLevels(levels map[Kind]zerolog.Level) HandlerOption {
+	return func(h *HTTPHandler) {
+		h.logLevels = make(map[Kind]zerolog.Level, len(levels))
+		for k, l := range levels {
+			h.logLevels[k] = l
+		}
+	}
+}
+
+// logLevel returns the level which the errors of given kind are logged at
+func (h *HTTPHandler) logLevel(kind Kind) zerolog.Level {
+	if l, ok := h.logLevels[kind]; ok {
+		return l
+	}
+
+	return zerolog.ErrorLevel
+}
+
+// WithLogSampling limits the logged errors with given LogSampler. As the sampler keeps
+// the counts of the errors, the same sampler is meant to be shared by every handler,
+// for example along the calls of HTTPRequestErrorHandler.
+func WithLogSampling(s *LogSampler) HandlerOption {
+	return func(h *HTTPHandler) {
+		h.logSampler = s
+	}
+}
+
+// maxLogWindows bounds the fingerprints tracked by LogSampler, once it is reached
+// the expired windows are dropped, or the oldest one if none is expired
+const maxLogWindows = 4096
+
+// LogSampler limits the logged errors to burst per period for each error fingerprint,
+// see Fingerprint. The errors beyond are not logged, instead a "suppressed N similar errors"
+// summary is logged at zerolog.WarnLevel once the period is over.
+//
+// A LogSampler is safe for concurrent use.
+type LogSampler struct {
+	burst  int
+	period time.Duration
+
+	now       func() time.Time
+	afterFunc func(time.Duration, func())
+
+	mu      sync.Mutex
+	windows map[string]*logWindow
+}
+
+// NewLogSampler returns a LogSampler logging burst errors per period for each error fingerprint
+func NewLogSampler(burst int, period time.Duration) *LogSampler {
+	return &LogSampler{
+		burst:     burst,
+		period:    period,
+		now:       time.Now,
+		afterFunc: func(d time.Duration, f func()) { time.AfterFunc(d, f) },
+		windows:   make(map[string]*logWindow),
+	}
+}
+
+// logWindow counts the errors of a fingerprint since start
+type logWindow struct {
+	start      time.Time
+	count      int
+	suppressed int
+}
+
+// summaryFunc logs the summary of suppressed errors of a fingerprint
+type summaryFunc func(fingerprint string, suppressed int, since time.Time)
+
+// allow tells whether the error of given fingerprint is logged. The summary of suppressed errors
+// is logged with given summary func, once the period of the first suppressed error is over.
+func (s *LogSampler) allow(fingerprint string, summary summaryFunc) bool {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, ok := s.windows[fingerprint]
+	if ok && now.Sub(w.start) >= s.period {
+		s.flush(fingerprint, w, summary)
+		ok = false
+	}
+
+	if !ok {
+		if len(s.windows) >= maxLogWindows {
+			s.sweep(now, summary)
+		}
+
+		w = &logWindow{start: now}
+		s.windows[fingerprint] = w
+	}
+
+	w.count++
+	if w.count <= s.burst {
+		return true
+	}
+
+	w.suppressed++
+	if w.suppressed == 1 {
+		s.afterFunc(w.start.Add(s.period).Sub(now), func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			if s.windows[fingerprint] == w {
+				s.flush(fingerprint, w, summary)
+			}
+		})
+	}
+
+	return false
+}
+
+// sweep drops the expired windows, or the oldest one if none is expired,
+// logging their summary of suppressed errors
+func (s *LogSampler) sweep(now time.Time, summary summaryFunc) {
+	var (
+		oldestFingerprint string
+		oldest            *logWindow
+	)
+
+	for fp, w := range s.windows {
+		if now.Sub(w.start) >= s.period {
+			s.flush(fp, w, summary)
+			continue
+		}
+
+		if oldest == nil || w.start.Before(oldest.start) {
+			oldestFingerprint, oldest = fp, w
+		}
+	}
+
+	if len(s.windows) >= maxLogWindows && oldest != nil {
+		s.flush(oldestFingerprint, oldest, summary)
+	}
+}
+
+// flush drops the window, logging its summary of suppressed errors, if any
+func (s *LogSampler) flush(fingerprint string, w *logWindow, summary summaryFunc) {
+	delete(s.windows, fingerprint)
+	if w.suppressed > 0 {
+		summary(fingerprint, w.suppressed, w.start)
+	}
+}
+
+// logSampled tells whether given error is logged, as sampled by the LogSampler, if any
+func (h *HTTPHandler) logSampled(lg handlerLog, err error) bool {
+	if h.logSampler == nil {
+		return true
+	}
+
+	return h.logSampler.allow(Fingerprint(err), lg.suppressed)
+}
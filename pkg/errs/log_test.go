@@ -0,0 +1,185 @@
+package errs_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLogLevels(t *testing.T) {
+	h := errs.NewHTTPHandler(errs.WithLogLevels(map[errs.Kind]zerolog.Level{
+		errs.NotExist: zerolog.InfoLevel,
+		errs.Invalid:  zerolog.Disabled,
+	}))
+
+	tests := []struct {
+		name      string
+		err       error
+		wantLevel string
+	}{
+		{name: "configured kind", err: errs.E(errs.NotExist, "not exist"), wantLevel: `"level":"info"`},
+		{name: "other kind", err: errs.E(errs.Database, "duplicate key"), wantLevel: `"level":"error"`},
+		{name: "unknown error", err: fmt.Errorf("unknown"), wantLevel: `"level":"error"`},
+		{name: "multiple errors", err: errs.Join(errs.E(errs.NotExist, "a"), errs.E(errs.NotExist, "b")), wantLevel: `"level":"info"`},
+		{name: "disabled kind", err: errs.E(errs.Invalid, "invalid operation")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h.Handle(httptest.NewRecorder(), nil, zerolog.New(&buf), tt.err)
+
+			if tt.wantLevel == "" {
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.Contains(t, buf.String(), tt.wantLevel)
+		})
+	}
+}
+
+// fakeClock is a clock of a LogSampler, which only moves on Advance
+type fakeClock struct {
+	now    time.Time
+	timers []fakeTimer
+}
+
+type fakeTimer struct {
+	at time.Time
+	f  func()
+}
+
+func newTestLogSampler(burst int, period time.Duration) (*errs.LogSampler, *fakeClock) {
+	c := &fakeClock{now: time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)}
+
+	s := errs.NewLogSampler(burst, period)
+	s.SetClock(func() time.Time { return c.now }, func(d time.Duration, f func()) {
+		c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), f: f})
+	})
+	return s, c
+}
+
+// Advance moves the clock, firing the timers which are due
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+
+	var pending []fakeTimer
+	for _, tm := range c.timers {
+		if tm.at.After(c.now) {
+			pending = append(pending, tm)
+			continue
+		}
+		tm.f()
+	}
+	c.timers = pending
+}
+
+func TestWithLogSampling(t *testing.T) {
+	newErr := func() error { return errs.E(errs.Database, "duplicate key") }
+
+	t.Run("summary on next error", func(t *testing.T) {
+		var buf bytes.Buffer
+		l := zerolog.New(&buf)
+		s, c := newTestLogSampler(2, time.Minute)
+		h := errs.NewHTTPHandler(errs.WithLogSampling(s))
+
+		for i := 0; i < 5; i++ {
+			h.Handle(httptest.NewRecorder(), nil, l, newErr())
+		}
+		h.Handle(httptest.NewRecorder(), nil, l, errs.E(errs.NotExist, "not exist"))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Len(t, lines, 3, "burst of similar errors, and a different one")
+
+		c.now = c.now.Add(time.Minute)
+		buf.Reset()
+		h.Handle(httptest.NewRecorder(), nil, l, newErr())
+
+		lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], `"level":"warn"`)
+		assert.Contains(t, lines[0], `"suppressed":3`)
+		assert.Contains(t, lines[0], `"message":"suppressed 3 similar errors"`)
+		assert.Contains(t, lines[1], `"level":"error"`)
+
+		buf.Reset()
+		c.Advance(time.Minute)
+		assert.Empty(t, buf.String(), "summary is logged once")
+	})
+
+	t.Run("summary on expiry", func(t *testing.T) {
+		var buf bytes.Buffer
+		l := zerolog.New(&buf)
+		s, c := newTestLogSampler(1, time.Minute)
+		h := errs.NewHTTPHandler(errs.WithLogSampling(s))
+
+		for i := 0; i < 3; i++ {
+			h.Handle(httptest.NewRecorder(), nil, l, newErr())
+		}
+
+		buf.Reset()
+		c.Advance(59 * time.Second)
+		assert.Empty(t, buf.String())
+
+		c.Advance(time.Second)
+		assert.Contains(t, buf.String(), `"message":"suppressed 2 similar errors"`)
+
+		buf.Reset()
+		h.Handle(httptest.NewRecorder(), nil, l, newErr())
+		assert.NotContains(t, buf.String(), "suppressed")
+	})
+
+	t.Run("unknown errors", func(t *testing.T) {
+		var buf bytes.Buffer
+		l := zerolog.New(&buf)
+		s, _ := newTestLogSampler(1, time.Minute)
+		h := errs.NewHTTPHandler(errs.WithLogSampling(s))
+
+		h.Handle(httptest.NewRecorder(), nil, l, fmt.Errorf("db down"))
+		h.Handle(httptest.NewRecorder(), nil, l, fmt.Errorf("payment provider 401"))
+		h.Handle(httptest.NewRecorder(), nil, l, fmt.Errorf("db down"))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2, "unrelated unknown errors are not similar")
+		assert.Contains(t, lines[0], "db down")
+		assert.Contains(t, lines[1], "payment provider 401")
+	})
+
+	t.Run("bounded fingerprints", func(t *testing.T) {
+		var buf bytes.Buffer
+		l := zerolog.New(&buf)
+		s, c := newTestLogSampler(1, time.Minute)
+		h := errs.NewHTTPHandler(errs.WithLogSampling(s))
+
+		h.Handle(httptest.NewRecorder(), nil, l, fmt.Errorf("error 0"))
+		h.Handle(httptest.NewRecorder(), nil, l, fmt.Errorf("error 0"))
+		for i := 1; i <= 4096; i++ {
+			c.now = c.now.Add(time.Millisecond)
+			h.Handle(httptest.NewRecorder(), nil, l, fmt.Errorf("error %d", i))
+		}
+
+		assert.Equal(t, 4096, s.Len())
+		assert.Contains(t, buf.String(), `"message":"suppressed 1 similar errors"`, "the oldest window is evicted with its summary")
+	})
+
+	t.Run("shared along the calls", func(t *testing.T) {
+		var buf bytes.Buffer
+		l := zerolog.New(&buf)
+		s, _ := newTestLogSampler(1, time.Minute)
+
+		for i := 0; i < 3; i++ {
+			errs.HTTPRequestErrorHandler(httptest.NewRecorder(), nil, l, newErr(), errs.WithLogSampling(s))
+		}
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Len(t, lines, 1)
+	})
+}
@@ -0,0 +1,112 @@
+package errs
+
+import (
+	"errors"
+	"strings"
+)
+
+// MultiError aggregates independent failures, such as closing several resources
+// or a batch operation, into a single error.
+// It supports errors.Is and errors.As over all of its members.
+type MultiError []error
+
+// Append appends the given errors, skipping the nil ones
+func (m *MultiError) Append(errs ...error) {
+	for _, err := range errs {
+		if err != nil {
+			*m = append(*m, err)
+		}
+	}
+}
+
+// ErrorOrNil returns nil if there is no error, or the MultiError otherwise
+func (m MultiError) ErrorOrNil() error {
+	if len(m) == 0 {
+		return nil
+	}
+
+	return m
+}
+
+func (m MultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the member errors
+func (m MultiError) Unwrap() []error {
+	return m
+}
+
+// Is reports whether any of the member errors matches target
+func (m MultiError) Is(target error) bool {
+	for _, err := range m {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first member error that matches target
+func (m MultiError) As(target interface{}) bool {
+	for _, err := range m {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Kind returns the most severe Kind among the member errors, which is the one
+// translated into the highest HTTP status code. A member that is not an *Error
+// is considered as Other.
+func (m MultiError) Kind() Kind {
+	kind, status := Other, 0
+	for _, err := range m {
+		k := KindOf(err)
+		if s := HTTPStatusCodeFromKind(k); s > status {
+			kind, status = k, s
+		}
+	}
+
+	return kind
+}
+
+// Join returns a MultiError of the given non-nil errors, or nil if there is none
+func Join(errs ...error) error {
+	var m MultiError
+	m.Append(errs...)
+	return m.ErrorOrNil()
+}
+
+// multiErrorOf returns the MultiError carried by err, either directly or through
+// its chain, along with the effective Kind, which is the outermost Kind set
+// or the most severe Kind of the members.
+func multiErrorOf(err error) (MultiError, Kind, bool) {
+	kind := Other
+	for err != nil {
+		switch v := err.(type) {
+		case MultiError:
+			if kind == Other {
+				kind = v.Kind()
+			}
+			return v, kind, true
+		case *Error:
+			if kind == Other {
+				kind = v.Kind
+			}
+			err = v.Err
+		default:
+			err = errors.Unwrap(err)
+		}
+	}
+
+	return nil, Other, false
+}
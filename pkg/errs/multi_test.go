@@ -0,0 +1,100 @@
+package errs_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiError(t *testing.T) {
+	t.Run("append and nil", func(t *testing.T) {
+		var m errs.MultiError
+		m.Append(nil, nil)
+		assert.Nil(t, m.ErrorOrNil())
+		assert.Nil(t, errs.Join(nil))
+
+		m.Append(fmt.Errorf("a"), nil, fmt.Errorf("b"))
+		assert.Len(t, m, 2)
+		assert.Equal(t, "a; b", m.ErrorOrNil().Error())
+	})
+
+	t.Run("errors.Is and errors.As over all members", func(t *testing.T) {
+		notExist := errs.E(errs.NotExist, errs.Code("product_not_exist"), "not exist")
+		err := errs.Join(fmt.Errorf("close: %w", fs.ErrClosed), notExist)
+
+		assert.True(t, errors.Is(err, fs.ErrClosed))
+		assert.False(t, errors.Is(err, fs.ErrNotExist))
+
+		var e *errs.Error
+		assert.True(t, errors.As(err, &e))
+		assert.Equal(t, errs.Code("product_not_exist"), e.Code)
+
+		wrapped := errs.E(errs.Op("batch.Close"), err)
+		assert.True(t, errors.Is(wrapped, fs.ErrClosed))
+	})
+
+	t.Run("most severe kind", func(t *testing.T) {
+		tests := []struct {
+			name string
+			m    errs.MultiError
+			want errs.Kind
+		}{
+			{"empty", errs.MultiError{}, errs.Other},
+			{"single", errs.MultiError{errs.E(errs.NotExist)}, errs.NotExist},
+			{"client and server errors", errs.MultiError{errs.E(errs.NotExist), errs.E(errs.Unavailable), errs.E(errs.Database)}, errs.Unavailable},
+			{"client errors", errs.MultiError{errs.E(errs.Validation), errs.E(errs.Exist)}, errs.Exist},
+			{"unknown error", errs.MultiError{errs.E(errs.NotExist), fmt.Errorf("unknown")}, errs.Other},
+			{"kind behind a wrap", errs.MultiError{errs.E(errs.Validation), errs.E(errs.Op("user.Get"), fmt.Errorf("repo: %w", errs.E(errs.NotExist, "x")))}, errs.NotExist},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.want, tt.m.Kind())
+			})
+		}
+
+		assert.True(t, errs.KindIs(errs.Exist, errs.E(errs.MultiError{errs.E(errs.Validation), errs.E(errs.Exist)})))
+		assert.True(t, errs.KindIs(errs.Invalid, errs.E(errs.Invalid, errs.MultiError{errs.E(errs.Exist)})))
+	})
+}
+
+func TestHTTPHandler_MultiError(t *testing.T) {
+	l := zerolog.New(os.Stdout).Level(zerolog.DebugLevel)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		want   string
+	}{
+		{"bare", errs.Join(
+			errs.E(errs.NotExist, errs.Code("product_not_exist"), errs.Parameter("items[0]"), "product 14 not exist"),
+			errs.E(errs.Exist, errs.Parameter("items[1]"), "product 15 already exist"),
+		), http.StatusConflict,
+			`{"errors":[{"kind":"resource_does_not_exist","code":"product_not_exist","param":"items[0]","pointer":"/items/0","message":"product 14 not exist"},{"kind":"resource_already_exists","param":"items[1]","pointer":"/items/1","message":"product 15 already exist"}]}`},
+		{"wrapped with hidden and unknown members", errs.E(errs.Op("batch.Close"), errs.Join(
+			errs.E(errs.Database, "connection reset"),
+			fmt.Errorf("unknown"),
+		)), http.StatusInternalServerError,
+			`{"errors":[{"kind":"database_error","message":"internal server error"},{"code":"unknown_error","message":"unknown error - please contact support"}]}`},
+		{"explicit kind", errs.E(errs.Invalid, errs.Join(errs.E(errs.Database, "connection reset"))), http.StatusNotAcceptable,
+			`{"errors":[{"kind":"database_error","message":"internal server error"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			errs.HTTPErrorHandler(w, l, tt.err)
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, tt.want, w.Body.String())
+		})
+	}
+}
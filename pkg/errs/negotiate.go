@@ -0,0 +1,91 @@
+package errs
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// acceptSpec is a single entry of an Accept-like header
+type acceptSpec struct {
+	value string
+	q     float64
+}
+
+// parseAccept parses an Accept-like header, such as Accept or Accept-Language,
+// into entries ordered by their quality value. Entries with q=0 are dropped.
+func parseAccept(header string) []acceptSpec {
+	var specs []acceptSpec
+
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		value := strings.ToLower(strings.TrimSpace(fields[0]))
+		if value == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			k, v, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(k) != "q" {
+				continue
+			}
+
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				q = f
+			}
+		}
+
+		if q <= 0 {
+			continue
+		}
+
+		specs = append(specs, acceptSpec{value: value, q: q})
+	}
+
+	sort.SliceStable(specs, func(i, j int) bool {
+		return specs[i].q > specs[j].q
+	})
+
+	return specs
+}
+
+// mediaTypeMatches reports whether the media type satisfies given media range
+func mediaTypeMatches(mediaRange, mediaType string) bool {
+	if mediaRange == "*/*" || mediaRange == mediaType {
+		return true
+	}
+
+	if strings.HasSuffix(mediaRange, "/*") {
+		return strings.HasPrefix(mediaType, strings.TrimSuffix(mediaRange, "*"))
+	}
+
+	return false
+}
+
+// negotiate picks the renderer for the given request, falling back to the handler renderer,
+// or the DefaultRenderer, when the request is nil or nothing acceptable is registered
+func (h *HTTPHandler) negotiate(w http.ResponseWriter, r *http.Request) Renderer {
+	fallback := h.renderer
+	if fallback == nil {
+		fallback = DefaultRenderer
+	}
+
+	if r == nil || len(h.renderers) == 0 {
+		return fallback
+	}
+
+	w.Header().Add("Vary", "Accept")
+
+	candidates := append([]Renderer{fallback}, h.renderers...)
+	for _, spec := range parseAccept(r.Header.Get("Accept")) {
+		for _, rd := range candidates {
+			if mediaTypeMatches(spec.value, rd.ContentType()) {
+				return rd
+			}
+		}
+	}
+
+	return fallback
+}
@@ -0,0 +1,102 @@
+package errs_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPRequestErrorHandler_Negotiation(t *testing.T) {
+	l := zerolog.New(os.Stdout).Level(zerolog.DebugLevel)
+	err := errs.E(errs.NotExist, errs.Code("product_not_exist"), "not exist")
+	renderers := errs.WithRenderers(errs.XMLRenderer{}, errs.HTMLRenderer{}, errs.TextRenderer{})
+
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{"no accept header", "", errs.MIMEApplicationJSON},
+		{"any", "*/*", errs.MIMEApplicationJSON},
+		{"json", "application/json", errs.MIMEApplicationJSON},
+		{"xml", "application/xml", errs.MIMEApplicationXML},
+		{"html from browser", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", errs.MIMETextHTML},
+		{"text by quality", "text/html;q=0.5, text/plain", errs.MIMETextPlain},
+		{"text wildcard", "text/*", errs.MIMETextHTML},
+		{"rejected by quality", "application/xml;q=0", errs.MIMEApplicationJSON},
+		{"unsupported", "image/png", errs.MIMEApplicationJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+
+			w := httptest.NewRecorder()
+			errs.HTTPRequestErrorHandler(w, r, l, err, renderers)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Equal(t, tt.want, w.Header().Get("Content-Type"))
+			assert.Equal(t, "Accept", w.Header().Get("Vary"))
+		})
+	}
+
+	t.Run("without registered renderers", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept", "application/xml")
+
+		w := httptest.NewRecorder()
+		errs.HTTPRequestErrorHandler(w, r, l, err)
+		assert.Equal(t, errs.MIMEApplicationJSON, w.Header().Get("Content-Type"))
+		assert.Empty(t, w.Header().Get("Vary"))
+	})
+}
+
+func TestRenderers(t *testing.T) {
+	l := zerolog.New(os.Stdout).Level(zerolog.DebugLevel)
+	verr := errs.E(errs.Validation, errs.ValidationErrors{
+		errs.E(errs.Parameter("key"), "bad format"),
+		errs.E(errs.Parameter("last_name"), "bad <format>"),
+	})
+
+	tests := []struct {
+		name     string
+		renderer errs.Renderer
+		err      error
+		want     string
+	}{
+		{"xml", errs.XMLRenderer{}, errs.E(errs.NotExist, errs.Code("product_not_exist"), "not exist"),
+			`<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<response><error><kind>resource_does_not_exist</kind><code>product_not_exist</code><message>not exist</message></error></response>`},
+		{"xml validation", errs.XMLRenderer{}, verr,
+			`<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<response><errors><error><param>key</param><pointer>/key</pointer><message>bad format</message></error><error><param>last_name</param><pointer>/last_name</pointer><message>bad &lt;format&gt;</message></error></errors></response>`},
+		{"text", errs.TextRenderer{}, errs.E(errs.NotExist, "not exist"), "not exist\n"},
+		{"text validation", errs.TextRenderer{}, verr, "key: bad format\nlast_name: bad <format>\n"},
+		{"html validation", errs.HTMLRenderer{}, verr, `<!DOCTYPE html>
+<html>
+<head><title>400 Bad Request</title></head>
+<body>
+<h1>Bad Request</h1>
+<ul>
+<li><strong>key</strong>: bad format</li>
+<li><strong>last_name</strong>: bad &lt;format&gt;</li>
+</ul>
+</body>
+</html>
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			errs.HTTPErrorHandler(w, l, tt.err, errs.WithRenderer(tt.renderer))
+			assert.Equal(t, tt.want, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package errs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Path builds a nested Parameter from its segments, where a string is a field name
+// and an int is the index of an array element, such as Path("items", 2, "sku")
+// for "items[2].sku".
+func Path(segments ...interface{}) Parameter {
+	var p Parameter
+	for _, seg := range segments {
+		switch seg := seg.(type) {
+		case int:
+			p = p.Index(seg)
+		case Parameter:
+			p = p.Field(string(seg))
+		case string:
+			p = p.Field(seg)
+		default:
+			p = p.Field(fmt.Sprint(seg))
+		}
+	}
+
+	return p
+}
+
+// Field returns the parameter of the named field nested in p, such as "items[2].sku"
+func (p Parameter) Field(name string) Parameter {
+	if p == "" {
+		return Parameter(name)
+	}
+
+	return p + "." + Parameter(name)
+}
+
+// Index returns the parameter of the array element of p at i, such as "items[2]"
+func (p Parameter) Index(i int) Parameter {
+	return p + "[" + Parameter(strconv.Itoa(i)) + "]"
+}
+
+// Segments splits p into its field names and indexes, such as
+// ["items", "2", "sku"] for "items[2].sku"
+func (p Parameter) Segments() []string {
+	var segs []string
+	var cur strings.Builder
+	flush := func() {
+		if cur.Len() > 0 {
+			segs = append(segs, cur.String())
+			cur.Reset()
+		}
+	}
+
+	for _, r := range string(p) {
+		switch r {
+		case '.', '[', ']':
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+
+	return segs
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Pointer returns the RFC 6901 JSON Pointer referencing p in the request document,
+// such as "/items/2/sku" for "items[2].sku"
+func (p Parameter) Pointer() string {
+	var b strings.Builder
+	for _, seg := range p.Segments() {
+		b.WriteString("/")
+		b.WriteString(pointerEscaper.Replace(seg))
+	}
+
+	return b.String()
+}
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// ParsePointer returns the Parameter referenced by the RFC 6901 JSON Pointer,
+// optionally as URI fragment, such as "items[2].sku" for "#/items/2/sku"
+func ParsePointer(pointer string) Parameter {
+	pointer = strings.TrimPrefix(pointer, "#")
+
+	var p Parameter
+	for _, seg := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if i, err := strconv.Atoi(seg); err == nil && p != "" {
+			p = p.Index(i)
+			continue
+		}
+		p = p.Field(pointerUnescaper.Replace(seg))
+	}
+
+	return p
+}
@@ -0,0 +1,39 @@
+package errs_test
+
+import (
+	"testing"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParameter(t *testing.T) {
+	tests := []struct {
+		name         string
+		param        errs.Parameter
+		wantSegments []string
+		wantPointer  string
+	}{
+		{name: "flat", param: "email", wantSegments: []string{"email"}, wantPointer: "/email"},
+		{name: "nested", param: errs.Path("address", "city"), wantSegments: []string{"address", "city"}, wantPointer: "/address/city"},
+		{name: "array element", param: errs.Path("items", 2, "sku"), wantSegments: []string{"items", "2", "sku"}, wantPointer: "/items/2/sku"},
+		{name: "nested arrays", param: errs.Path("matrix", 0, 1), wantSegments: []string{"matrix", "0", "1"}, wantPointer: "/matrix/0/1"},
+		{name: "escaped", param: "a/b~c", wantSegments: []string{"a/b~c"}, wantPointer: "/a~1b~0c"},
+		{name: "empty", param: "", wantPointer: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantSegments, tt.param.Segments())
+			assert.Equal(t, tt.wantPointer, tt.param.Pointer())
+			assert.Equal(t, tt.param, errs.ParsePointer(tt.wantPointer))
+			assert.Equal(t, tt.param, errs.ParsePointer("#"+tt.wantPointer))
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	assert.Equal(t, errs.Parameter("items[2].sku"), errs.Path("items", 2, "sku"))
+	assert.Equal(t, errs.Parameter("items[2].sku"), errs.Path(errs.Parameter("items").Index(2), "sku"))
+	assert.Equal(t, errs.Parameter("items[2].sku"), errs.Parameter("items").Index(2).Field("sku"))
+}
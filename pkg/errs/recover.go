@@ -0,0 +1,96 @@
+package errs
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// Recover returns a middleware recovering from the panics of next. The panic is translated
+// into an Internal *Error holding the panic value and stack, which is then handled by h,
+// using the logger of the request context, see zerolog.Ctx, and reported
+// to the Reporter set by WithReporter, if any.
+//
+// The http.ErrAbortHandler panic is left to net/http, as it is meant to abort the response.
+func (h *HTTPHandler) Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+
+			h.handle(w, r, h.zerologLog(*zerolog.Ctx(r.Context())), fromPanic(v), true)
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RecoverHandler is the same as HTTPHandler.Recover, using the default HTTPHandler
+func RecoverHandler(next http.Handler, opts ...HandlerOption) http.Handler {
+	return NewHTTPHandler(opts...).Recover(next)
+}
+
+// Safe runs fn, translating its panic, if any, into an Internal *Error
+func Safe(fn func() error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fromPanic(v)
+		}
+	}()
+
+	return fn()
+}
+
+// Go runs fn in a new goroutine, recovering from its panic. The error returned by fn,
+// or its panic translated into an Internal *Error, is passed to handle, if not nil.
+func Go(fn func() error, handle func(error)) {
+	go func() {
+		if err := Safe(fn); err != nil && handle != nil {
+			handle(err)
+		}
+	}()
+}
+
+// fromPanic translates the recovered panic value into an Internal *Error,
+// it must be called by the deferred function recovering the panic
+func fromPanic(v interface{}) error {
+	var cause error
+	if err, ok := v.(error); ok {
+		cause = fmt.Errorf("panic: %w", err)
+	} else {
+		cause = fmt.Errorf("panic: %v", v)
+	}
+
+	return &Error{
+		Kind:   Internal,
+		Fields: Fields{"panic": fmt.Sprint(v)},
+		Err:    cause,
+		stack:  panicStack(),
+	}
+}
+
+// panicStack captures the stack of the panicking goroutine, starting from the first frame
+// outside of the runtime, which is the frame that panicked, be it by calling panic or by
+// a runtime error such as a nil pointer dereference.
+func panicStack() stack {
+	// skip runtime.Callers, callers, panicStack, fromPanic and the deferred function
+	st := callers(5)
+
+	for i := range st {
+		fr, _ := runtime.CallersFrames(st[i : i+1]).Next()
+		if !strings.HasPrefix(fr.Function, "runtime.") {
+			return st[i:]
+		}
+	}
+
+	return st
+}
@@ -0,0 +1,113 @@
+package errs_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func panicky() error {
+	panic("boom")
+}
+
+type panickyUser struct{ name string }
+
+func nilPanicky() error {
+	var u *panickyUser
+	return errors.New(u.name)
+}
+
+func TestRecover(t *testing.T) {
+	l := zerolog.New(os.Stdout).Level(zerolog.DebugLevel)
+
+	t.Run("panic is rendered as internal error", func(t *testing.T) {
+		h := errs.RecoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		}))
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		h.ServeHTTP(w, r.WithContext(l.WithContext(r.Context())))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, `{"error":{"kind":"internal_error","message":"internal server error"}}`, w.Body.String())
+	})
+
+	t.Run("no panic", func(t *testing.T) {
+		h := errs.NewHTTPHandler().Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("abort handler is re-panicked", func(t *testing.T) {
+		h := errs.RecoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		}))
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+}
+
+func TestSafe(t *testing.T) {
+	t.Run("panic value", func(t *testing.T) {
+		err := errs.Safe(panicky)
+		require.Error(t, err)
+
+		assert.True(t, errs.KindIs(errs.Internal, err))
+		assert.Equal(t, "panic: boom", err.Error())
+		assert.Equal(t, "boom", err.(*errs.Error).Fields["panic"])
+
+		frames := errs.StackTrace(err)
+		require.NotEmpty(t, frames)
+		assert.True(t, strings.HasSuffix(frames[0].Function, "errs_test.panicky"), frames[0].Function)
+	})
+
+	t.Run("runtime error", func(t *testing.T) {
+		err := errs.Safe(nilPanicky)
+		require.Error(t, err)
+
+		assert.True(t, errs.KindIs(errs.Internal, err))
+		assert.Contains(t, err.Error(), "nil pointer dereference")
+
+		frames := errs.StackTrace(err)
+		require.NotEmpty(t, frames)
+		assert.True(t, strings.HasSuffix(frames[0].Function, "errs_test.nilPanicky"), frames[0].Function)
+	})
+
+	t.Run("panic error", func(t *testing.T) {
+		cause := errors.New("nil map")
+		err := errs.Safe(func() error { panic(cause) })
+
+		assert.True(t, errs.KindIs(errs.Internal, err))
+		assert.True(t, errors.Is(err, cause))
+	})
+
+	t.Run("returned error", func(t *testing.T) {
+		err := errs.E(errs.NotExist, "not exist")
+		assert.Equal(t, err, errs.Safe(func() error { return err }))
+		assert.NoError(t, errs.Safe(func() error { return nil }))
+	})
+}
+
+func TestGo(t *testing.T) {
+	done := make(chan error)
+	errs.Go(panicky, func(err error) { done <- err })
+
+	err := <-done
+	assert.True(t, errs.KindIs(errs.Internal, err))
+	assert.Equal(t, "panic: boom", err.Error())
+}
@@ -0,0 +1,168 @@
+package errs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// RedactedMask replaces the redacted data unless Redactor.Mask is set
+const RedactedMask = "[REDACTED]"
+
+// Common patterns of sensitive data, to be used as Redactor.Patterns
+var (
+	PatternEmail       = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	PatternBearerToken = regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9\-._~+/]+=*`)
+)
+
+// DefaultRedactor is the redaction policy applied to the errors before they are logged,
+// through the handler or the zerolog and slog marshaling, and rendered by the handler.
+// It is nil by default, meaning no redaction.
+var DefaultRedactor *Redactor
+
+// UserRedaction tells how the user identifiers are redacted
+type UserRedaction uint8
+
+const (
+	UserVerbatim UserRedaction = iota // User is kept as is
+	UserMasked                        // User is masked except its first and last character, such as "j**n"
+	UserHashed                        // User is replaced by its hash, still correlating the errors of a user
+)
+
+// Redactor is a redaction policy scrubbing sensitive data out of the errors.
+// A nil Redactor keeps everything as is.
+type Redactor struct {
+	// SensitiveFields are the keys of Fields which value is masked, case-insensitive.
+	SensitiveFields []string
+
+	// User tells how the User of the error is redacted.
+	User UserRedaction
+
+	// Salt is prepended to the user identifier before hashing, see UserHashed.
+	Salt string
+
+	// Patterns are the regular expressions which matches are masked in messages.
+	Patterns []*regexp.Regexp
+
+	// Mask replaces the redacted data, RedactedMask is used if left unset.
+	Mask string
+}
+
+func (rd *Redactor) mask() string {
+	if rd.Mask == "" {
+		return RedactedMask
+	}
+
+	return rd.Mask
+}
+
+// Message returns msg with the matches of the patterns masked
+func (rd *Redactor) Message(msg string) string {
+	if rd == nil {
+		return msg
+	}
+
+	for _, p := range rd.Patterns {
+		msg = p.ReplaceAllString(msg, rd.mask())
+	}
+
+	return msg
+}
+
+// UserName returns the user identifier redacted according to the policy
+func (rd *Redactor) UserName(u UserName) string {
+	if rd == nil || u == "" {
+		return string(u)
+	}
+
+	switch rd.User {
+	case UserMasked:
+		r := []rune(u)
+		if len(r) <= 2 {
+			return strings.Repeat("*", len(r))
+		}
+		return string(r[0]) + strings.Repeat("*", len(r)-2) + string(r[len(r)-1])
+	case UserHashed:
+		sum := sha256.Sum256([]byte(rd.Salt + string(u)))
+		return "sha256:" + hex.EncodeToString(sum[:8])
+	default:
+		return string(u)
+	}
+}
+
+// Fields returns a copy of f with the value of the sensitive fields masked,
+// and the string values scrubbed by the patterns
+func (rd *Redactor) Fields(f Fields) Fields {
+	if rd == nil || len(f) == 0 {
+		return f
+	}
+
+	out := make(Fields, len(f))
+	for k, v := range f {
+		switch {
+		case rd.sensitive(k):
+			out[k] = rd.mask()
+		default:
+			if s, ok := v.(string); ok {
+				v = rd.Message(s)
+			}
+			out[k] = v
+		}
+	}
+
+	return out
+}
+
+func (rd *Redactor) sensitive(key string) bool {
+	for _, k := range rd.SensitiveFields {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sensitive marks the value as sensitive, such as the value of a Field, which is
+// always masked when printed, logged or marshaled into JSON, regardless of DefaultRedactor:
+//
+//	errs.E(errs.Field("token", errs.Sensitive(token)), err)
+func Sensitive(v interface{}) interface{} {
+	return sensitive{v}
+}
+
+type sensitive struct {
+	v interface{}
+}
+
+func (s sensitive) String() string {
+	return RedactedMask
+}
+
+func (s sensitive) MarshalJSON() ([]byte, error) {
+	return json.Marshal(RedactedMask)
+}
+
+// redactedError scrubs the message of err with DefaultRedactor, it is used to log
+// errors which are not an *Error, and are thus not redacted by themselves
+type redactedError struct {
+	err error
+}
+
+func redacted(err error) error {
+	if DefaultRedactor == nil || err == nil {
+		return err
+	}
+
+	return &redactedError{err}
+}
+
+func (r *redactedError) Error() string {
+	return DefaultRedactor.Message(r.err.Error())
+}
+
+func (r *redactedError) Unwrap() error {
+	return r.err
+}
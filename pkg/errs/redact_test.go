@@ -0,0 +1,100 @@
+package errs_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactor(t *testing.T) {
+	rd := &errs.Redactor{
+		SensitiveFields: []string{"token"},
+		Patterns:        []*regexp.Regexp{errs.PatternEmail, errs.PatternBearerToken},
+	}
+
+	t.Run("message", func(t *testing.T) {
+		assert.Equal(t, "user [REDACTED] sent [REDACTED]", rd.Message("user john@doe.com sent Bearer eyJhbGciOi.J9x"))
+		assert.Equal(t, "no sensitive data", rd.Message("no sensitive data"))
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		f := errs.Fields{"Token": "secret", "email": "john@doe.com", "entity_id": 14}
+		assert.Equal(t, errs.Fields{"Token": "[REDACTED]", "email": "[REDACTED]", "entity_id": 14}, rd.Fields(f))
+		assert.Equal(t, "secret", f["Token"], "fields are copied")
+	})
+
+	t.Run("user name", func(t *testing.T) {
+		tests := []struct {
+			name string
+			rd   *errs.Redactor
+			user errs.UserName
+			want string
+		}{
+			{name: "verbatim", rd: &errs.Redactor{}, user: "john", want: "john"},
+			{name: "masked", rd: &errs.Redactor{User: errs.UserMasked}, user: "john", want: "j**n"},
+			{name: "masked short", rd: &errs.Redactor{User: errs.UserMasked}, user: "jo", want: "**"},
+			{name: "hashed", rd: &errs.Redactor{User: errs.UserHashed}, user: "john", want: "sha256:96d9632f363564cc"},
+			{name: "hashed with salt", rd: &errs.Redactor{User: errs.UserHashed, Salt: "pepper"}, user: "john", want: "sha256:8804d173b639667f"},
+			{name: "nil redactor", user: "john", want: "john"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.want, tt.rd.UserName(tt.user))
+			})
+		}
+	})
+
+	t.Run("custom mask", func(t *testing.T) {
+		rd := &errs.Redactor{Patterns: []*regexp.Regexp{errs.PatternEmail}, Mask: "***"}
+		assert.Equal(t, "user ***", rd.Message("user john@doe.com"))
+	})
+}
+
+func TestSensitive(t *testing.T) {
+	var buf bytes.Buffer
+	l := zerolog.New(&buf)
+	l.Log().Err(errs.E(errs.Field("token", errs.Sensitive("secret")), "failed")).Send()
+
+	assert.Contains(t, buf.String(), `"fields":{"token":"[REDACTED]"}`)
+	assert.Equal(t, "[REDACTED]", fmt.Sprint(errs.Sensitive("secret")))
+}
+
+func TestDefaultRedactor(t *testing.T) {
+	errs.DefaultRedactor = &errs.Redactor{
+		SensitiveFields: []string{"token"},
+		User:            errs.UserMasked,
+		Patterns:        []*regexp.Regexp{errs.PatternEmail},
+	}
+	t.Cleanup(func() { errs.DefaultRedactor = nil })
+
+	t.Run("logging", func(t *testing.T) {
+		var buf bytes.Buffer
+		l := zerolog.New(&buf)
+		l.Log().Err(errs.E(errs.UserName("john"), errs.Field("token", "secret"), "duplicate key john@doe.com")).Send()
+
+		assert.JSONEq(t, `{"error":{"message":"duplicate key [REDACTED]","kind":"other_error","user":"j**n","fields":{"token":"[REDACTED]"}}}`, buf.String())
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := httptest.NewRecorder()
+		errs.HTTPErrorHandler(w, zerolog.New(&buf), errs.E(errs.Exist, "user john@doe.com already exists"))
+
+		assert.Equal(t, `{"error":{"kind":"resource_already_exists","message":"user [REDACTED] already exists"}}`, w.Body.String())
+		assert.NotContains(t, buf.String(), "john@doe.com")
+	})
+
+	t.Run("handler unknown error", func(t *testing.T) {
+		var buf bytes.Buffer
+		errs.HTTPErrorHandler(httptest.NewRecorder(), zerolog.New(&buf), fmt.Errorf("dial john@doe.com"))
+
+		assert.Contains(t, buf.String(), `"error":"dial [REDACTED]"`)
+	})
+}
@@ -0,0 +1,231 @@
+package errs
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"net/http"
+)
+
+const (
+	// MIMEApplicationJSON is the media type used by JSONRenderer
+	MIMEApplicationJSON = "application/json"
+
+	// MIMEApplicationProblemJSON is the media type used by ProblemRenderer
+	MIMEApplicationProblemJSON = "application/problem+json"
+
+	// MIMEApplicationXML is the media type used by XMLRenderer
+	MIMEApplicationXML = "application/xml"
+
+	// MIMETextHTML is the media type used by HTMLRenderer
+	MIMETextHTML = "text/html"
+
+	// MIMETextPlain is the media type used by TextRenderer
+	MIMETextPlain = "text/plain"
+)
+
+// Renderer translates an error response into the HTTP response body.
+type Renderer interface {
+	// ContentType returns the media type of the rendered body.
+	ContentType() string
+
+	// Render encodes the response body for the given status code and error kind.
+	Render(status int, kind Kind, resp HTTPErrResponse) ([]byte, error)
+}
+
+// DefaultRenderer is the renderer used by HTTPRequestErrorHandler
+// when no renderer is given through WithRenderer.
+var DefaultRenderer Renderer = JSONRenderer{}
+
+// JSONRenderer renders the HTTPErrResponse as is.
+type JSONRenderer struct{}
+
+// ContentType returns application/json
+func (JSONRenderer) ContentType() string {
+	return MIMEApplicationJSON
+}
+
+// Render encodes resp as JSON
+func (JSONRenderer) Render(_ int, _ Kind, resp HTTPErrResponse) ([]byte, error) {
+	return json.Marshal(resp)
+}
+
+// XMLRenderer renders the HTTPErrResponse as an XML document.
+type XMLRenderer struct{}
+
+// ContentType returns application/xml
+func (XMLRenderer) ContentType() string {
+	return MIMEApplicationXML
+}
+
+// Render encodes resp as XML, rooted at the <response> element
+func (XMLRenderer) Render(_ int, _ Kind, resp HTTPErrResponse) ([]byte, error) {
+	type errorList struct {
+		Errors []ServiceError `xml:"error"`
+	}
+
+	doc := struct {
+		XMLName xml.Name      `xml:"response"`
+		Error   *ServiceError `xml:"error,omitempty"`
+		Errors  *errorList    `xml:"errors,omitempty"`
+	}{Error: resp.Error}
+
+	if len(resp.Errors) > 0 {
+		doc.Errors = &errorList{Errors: resp.Errors}
+	}
+
+	body, err := xml.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+// TextRenderer renders the HTTPErrResponse as plain text, one error per line.
+type TextRenderer struct{}
+
+// ContentType returns text/plain
+func (TextRenderer) ContentType() string {
+	return MIMETextPlain
+}
+
+// Render writes the error message, or each of the errors prefixed with its parameter
+func (TextRenderer) Render(_ int, _ Kind, resp HTTPErrResponse) ([]byte, error) {
+	buff := bytes.NewBufferString("")
+
+	if resp.Error != nil {
+		buff.WriteString(resp.Error.Message)
+		buff.WriteString("\n")
+	}
+
+	for _, se := range resp.Errors {
+		if se.Param != "" {
+			buff.WriteString(fmt.Sprintf("%s: ", se.Param))
+		}
+		buff.WriteString(se.Message)
+		buff.WriteString("\n")
+	}
+
+	return buff.Bytes(), nil
+}
+
+// DefaultHTMLTemplate is the template used by HTMLRenderer when no template is given
+var DefaultHTMLTemplate = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html>
+<head><title>{{.Status}} {{.Title}}</title></head>
+<body>
+<h1>{{.Title}}</h1>
+{{with .Error}}<p>{{.Message}}</p>
+{{end}}{{with .Errors}}<ul>
+{{range .}}<li>{{with .Param}}<strong>{{.}}</strong>: {{end}}{{.Message}}</li>
+{{end}}</ul>
+{{end}}</body>
+</html>
+`))
+
+// HTMLRenderer renders the HTTPErrResponse as an HTML page.
+type HTMLRenderer struct {
+	// Template is executed with the Status, Title, Error and Errors fields.
+	// If left unset, DefaultHTMLTemplate is used.
+	Template *template.Template
+}
+
+// ContentType returns text/html
+func (HTMLRenderer) ContentType() string {
+	return MIMETextHTML
+}
+
+// Render executes the template against resp
+func (h HTMLRenderer) Render(status int, _ Kind, resp HTTPErrResponse) ([]byte, error) {
+	tmpl := h.Template
+	if tmpl == nil {
+		tmpl = DefaultHTMLTemplate
+	}
+
+	var buff bytes.Buffer
+	err := tmpl.Execute(&buff, struct {
+		Status int
+		Title  string
+		HTTPErrResponse
+	}{
+		Status:          status,
+		Title:           http.StatusText(status),
+		HTTPErrResponse: resp,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buff.Bytes(), nil
+}
+
+// ProblemDetails is the RFC 9457 problem details object.
+type ProblemDetails struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+
+	// Code is an extension member carrying the error Code
+	Code string `json:"code,omitempty"`
+
+	// Errors is an extension member carrying the validation errors
+	Errors []ProblemError `json:"errors,omitempty"`
+}
+
+// ProblemError describes a single invalid input of a problem details object.
+type ProblemError struct {
+	// Pointer is a JSON Pointer, as URI fragment, to the invalid input
+	Pointer string `json:"pointer,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Detail  string `json:"detail"`
+}
+
+// ProblemRenderer renders the error response as RFC 9457 problem details.
+type ProblemRenderer struct {
+	// TypeBaseURI is prepended to the Kind name to build the problem type URI.
+	// If left unset, "urn:problem-type:" is used.
+	TypeBaseURI string
+}
+
+// ContentType returns application/problem+json
+func (ProblemRenderer) ContentType() string {
+	return MIMEApplicationProblemJSON
+}
+
+// Render encodes resp as problem details
+func (p ProblemRenderer) Render(status int, kind Kind, resp HTTPErrResponse) ([]byte, error) {
+	base := p.TypeBaseURI
+	if base == "" {
+		base = "urn:problem-type:"
+	}
+
+	problem := ProblemDetails{
+		Type:   base + kind.String(),
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+
+	if resp.Error != nil {
+		problem.Detail = resp.Error.Message
+		problem.Code = resp.Error.Code
+	}
+
+	for _, se := range resp.Errors {
+		pe := ProblemError{
+			Code:   se.Code,
+			Detail: se.Message,
+		}
+
+		if se.Param != "" {
+			pe.Pointer = "#" + Parameter(se.Param).Pointer()
+		}
+
+		problem.Errors = append(problem.Errors, pe)
+	}
+
+	return json.Marshal(problem)
+}
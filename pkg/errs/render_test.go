@@ -0,0 +1,96 @@
+package errs_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblemRenderer(t *testing.T) {
+	l := zerolog.New(os.Stdout).Level(zerolog.DebugLevel)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		want   string
+	}{
+		{
+			"common error",
+			errs.E(errs.NotExist, errs.Code("product_not_exist"), "the product resource for id=14 is not exist"),
+			http.StatusNotFound,
+			`{"type":"urn:problem-type:resource_does_not_exist","title":"Not Found","status":404,"detail":"the product resource for id=14 is not exist","code":"product_not_exist"}`,
+		},
+		{
+			"Validation",
+			errs.E(errs.Validation, errs.ValidationErrors{
+				errs.E(errs.Parameter("key"), errs.Code("bad_format"), "bad format"),
+				errs.E(errs.Parameter("a/b"), "bad format"),
+			}),
+			http.StatusBadRequest,
+			`{"type":"urn:problem-type:input_validation_error","title":"Bad Request","status":400,"errors":[{"pointer":"#/key","code":"bad_format","detail":"bad format"},{"pointer":"#/a~1b","detail":"bad format"}]}`,
+		},
+		{
+			"unknown error",
+			fmt.Errorf("example of unknown error"),
+			http.StatusNotImplemented,
+			`{"type":"urn:problem-type:other_error","title":"Not Implemented","status":501,"detail":"unknown error - please contact support","code":"unknown_error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			errs.HTTPErrorHandler(w, l, tt.err, errs.WithRenderer(errs.ProblemRenderer{}))
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, errs.MIMEApplicationProblemJSON, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.want, w.Body.String())
+		})
+	}
+
+	t.Run("custom type base URI", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		errs.HTTPErrorHandler(w, l, errs.E(errs.Exist, "already exist"),
+			errs.WithRenderer(errs.ProblemRenderer{TypeBaseURI: "https://example.com/problems/"}),
+		)
+
+		assert.Equal(t, `{"type":"https://example.com/problems/resource_already_exists","title":"Conflict","status":409,"detail":"already exist"}`, w.Body.String())
+	})
+}
+
+func TestDefaultRenderer(t *testing.T) {
+	l := zerolog.New(os.Stdout).Level(zerolog.DebugLevel)
+
+	defer func(r errs.Renderer) { errs.DefaultRenderer = r }(errs.DefaultRenderer)
+	errs.DefaultRenderer = errs.ProblemRenderer{}
+
+	w := httptest.NewRecorder()
+	errs.HTTPErrorHandler(w, l, errs.E(errs.Internal, "internal"))
+	assert.Equal(t, errs.MIMEApplicationProblemJSON, w.Header().Get("Content-Type"))
+
+	w = httptest.NewRecorder()
+	errs.HTTPErrorHandler(w, l, errs.E(errs.Internal, "internal"), errs.WithRenderer(errs.JSONRenderer{}))
+	assert.Equal(t, errs.MIMEApplicationJSON, w.Header().Get("Content-Type"))
+	assert.Equal(t, `{"error":{"kind":"internal_error","message":"internal server error"}}`, w.Body.String())
+}
+
+func TestJSONRenderer_Pointer(t *testing.T) {
+	l := zerolog.New(os.Stdout).Level(zerolog.DebugLevel)
+
+	w := httptest.NewRecorder()
+	errs.HTTPRequestErrorHandler(w, httptest.NewRequest(http.MethodPost, "/orders", nil), l, errs.E(errs.Validation, errs.ValidationErrors{
+		errs.E(errs.Parameter("items[2].sku"), "sku is required"),
+	}))
+	assert.Equal(t, `{"errors":[{"param":"items[2].sku","pointer":"/items/2/sku","message":"sku is required"}]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	errs.HTTPRequestErrorHandler(w, httptest.NewRequest(http.MethodPost, "/orders", nil), l, errs.E(errs.NotExist, errs.Parameter("items[0].sku"), "sku not exist"))
+	assert.Equal(t, `{"error":{"kind":"resource_does_not_exist","param":"items[0].sku","pointer":"/items/0/sku","message":"sku not exist"}}`, w.Body.String())
+}
@@ -0,0 +1,161 @@
+package errs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Report is an error forwarded to a Reporter
+type Report struct {
+	// Err is the reported error.
+	Err error
+
+	// Fingerprint groups similar errors together, see Fingerprint.
+	Fingerprint string
+
+	// Status is the HTTP status code responded for the error.
+	Status int
+
+	// Request is the HTTP request which failed, it may be nil.
+	Request *http.Request
+}
+
+// Reporter forwards errors to an external crash reporting service, such as Sentry or Rollbar
+type Reporter interface {
+	Report(rep Report)
+}
+
+// ReporterFunc is an adapter to use an ordinary function as a Reporter
+type ReporterFunc func(rep Report)
+
+// Report calls fn(rep)
+func (fn ReporterFunc) Report(rep Report) {
+	fn(rep)
+}
+
+// DefaultReportKinds are the error kinds reported by HTTPHandler unless set by WithReporter.
+// Errors that are not an *Error are always reported.
+var DefaultReportKinds = []Kind{Internal, Database}
+
+// WithReporter forwards the handled errors of given kinds, or DefaultReportKinds if none given,
+// to the reporter. Errors that are not an *Error, and panics recovered
+// by HTTPHandler.Recover, are always reported.
+func WithReporter(rep Reporter, kinds ...Kind) HandlerOption {
+	return func(h *HTTPHandler) {
+		if len(kinds) == 0 {
+			kinds = DefaultReportKinds
+		}
+
+		h.reporter = rep
+		h.reportKinds = make(map[Kind]bool, len(kinds))
+		for _, k := range kinds {
+			h.reportKinds[k] = true
+		}
+	}
+}
+
+// report forwards the error to the reporter, if its kind is reportable or force is set
+func (h *HTTPHandler) report(r *http.Request, status int, err error, force bool) {
+	if h.reporter == nil || err == nil || (!force && !h.reportable(err)) {
+		return
+	}
+
+	h.reporter.Report(Report{
+		Err:         err,
+		Fingerprint: Fingerprint(err),
+		Status:      status,
+		Request:     r,
+	})
+}
+
+// reportable tells whether the kind of the error is reported
+func (h *HTTPHandler) reportable(err error) bool {
+	if _, kind, ok := multiErrorOf(err); ok {
+		return h.reportKinds[kind]
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return h.reportKinds[KindOf(err)]
+	}
+
+	return true
+}
+
+// Fingerprint returns a short, stable identifier of the error, grouping similar errors together.
+// It is derived from the Kind, Code and trail of operations of the error, along with the
+// function of its top stack frame. For errors that are not an *Error, which have none of these,
+// the Go type and the message are used instead.
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var parts []string
+	var e *Error
+	if errors.As(err, &e) {
+		parts = append(parts, KindOf(err).String(), string(e.Code))
+	} else {
+		parts = append(parts, fmt.Sprintf("%T", err), err.Error())
+	}
+
+	var ops []string
+	for _, op := range Ops(err) {
+		ops = append(ops, string(op))
+	}
+	parts = append(parts, strings.Join(ops, ","))
+
+	if frames := StackTrace(err); len(frames) > 0 {
+		parts = append(parts, frames[0].Function)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// SampleReporter returns a Reporter forwarding only the given rate, between 0 and 1,
+// of the reports to rep
+func SampleReporter(rep Reporter, rate float64) Reporter {
+	return ReporterFunc(func(r Report) {
+		if rate >= 1 || (rate > 0 && rand.Float64() < rate) {
+			rep.Report(r)
+		}
+	})
+}
+
+// MemoryReporter is a Reporter keeping the reports in memory, such as for tests.
+// It is safe for concurrent use.
+type MemoryReporter struct {
+	mu      sync.Mutex
+	reports []Report
+}
+
+// Report keeps the report in memory
+func (m *MemoryReporter) Report(rep Report) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.reports = append(m.reports, rep)
+}
+
+// Reports returns the kept reports, in the order they were reported
+func (m *MemoryReporter) Reports() []Report {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Report(nil), m.reports...)
+}
+
+// Reset drops the kept reports
+func (m *MemoryReporter) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.reports = nil
+}
@@ -0,0 +1,103 @@
+package errs_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprint(t *testing.T) {
+	err := errs.E(errs.Op("user.Create"), errs.Database, errs.Code("duplicate_user"), "duplicate key")
+
+	fp := errs.Fingerprint(err)
+	assert.Len(t, fp, 16)
+
+	assert.Equal(t, fp, errs.Fingerprint(errs.E(errs.Op("user.Create"), errs.Database, errs.Code("duplicate_user"), "duplicate key")), "similar errors")
+	assert.Equal(t, fp, errs.Fingerprint(fmt.Errorf("wrapped: %w", err)), "wrapped error")
+	assert.NotEqual(t, fp, errs.Fingerprint(errs.E(errs.Op("user.Create"), errs.Database, errs.Code("duplicate_email"), "duplicate key")), "different code")
+	assert.NotEqual(t, fp, errs.Fingerprint(func() error {
+		return errs.E(errs.Op("user.Create"), errs.Database, errs.Code("duplicate_user"), "duplicate key")
+	}()), "different stack")
+	assert.Equal(t, errs.Fingerprint(fmt.Errorf("db down")), errs.Fingerprint(fmt.Errorf("db down")), "similar unknown errors")
+	assert.NotEqual(t, errs.Fingerprint(fmt.Errorf("db down")), errs.Fingerprint(fmt.Errorf("payment provider 401")), "different unknown errors")
+	assert.NotEqual(t, fp, errs.Fingerprint(fmt.Errorf("duplicate key")), "unknown error")
+	assert.Empty(t, errs.Fingerprint(nil))
+}
+
+func TestReporter(t *testing.T) {
+	l := zerolog.New(os.Stdout).Level(zerolog.DebugLevel)
+
+	tests := []struct {
+		name  string
+		kinds []errs.Kind
+		err   error
+		want  bool
+	}{
+		{name: "database", err: errs.E(errs.Op("user.Create"), errs.Database, errs.Code("duplicate_user"), "duplicate key"), want: true},
+		{name: "internal", err: errs.E(errs.Internal, "inconsistent state"), want: true},
+		{name: "unknown", err: fmt.Errorf("unknown"), want: true},
+		{name: "not exist", err: errs.E(errs.NotExist, "not exist"), want: false},
+		{name: "kind behind a wrap", err: errs.E(errs.Op("user.Get"), fmt.Errorf("repo: %w", errs.E(errs.NotExist, "x"))), want: false},
+		{name: "validation", err: errs.E(errs.Validation, errs.ValidationErrors{}), want: false},
+		{name: "nil", err: nil, want: false},
+		{name: "multiple errors", err: errs.Join(errs.E(errs.Internal, "a"), errs.E(errs.NotExist, "b")), want: true},
+		{name: "custom kinds", kinds: []errs.Kind{errs.NotExist}, err: errs.E(errs.NotExist, "not exist"), want: true},
+		{name: "custom kinds skips default", kinds: []errs.Kind{errs.NotExist}, err: errs.E(errs.Internal, "internal"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rep := &errs.MemoryReporter{}
+			r := httptest.NewRequest(http.MethodGet, "/users", nil)
+			errs.NewHTTPHandler(errs.WithReporter(rep, tt.kinds...)).Handle(httptest.NewRecorder(), r, l, tt.err)
+
+			if !tt.want {
+				assert.Empty(t, rep.Reports())
+				return
+			}
+
+			reports := rep.Reports()
+			require.Len(t, reports, 1)
+			assert.Equal(t, tt.err, reports[0].Err)
+			assert.Equal(t, errs.Fingerprint(tt.err), reports[0].Fingerprint)
+			assert.Equal(t, r, reports[0].Request)
+			assert.Equal(t, errs.HTTPStatusCodeFromError(tt.err), reports[0].Status)
+		})
+	}
+
+	t.Run("recovered panic", func(t *testing.T) {
+		rep := &errs.MemoryReporter{}
+		h := errs.NewHTTPHandler(errs.WithReporter(rep, errs.Database)).Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		}))
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		reports := rep.Reports()
+		require.Len(t, reports, 1)
+		assert.True(t, errs.KindIs(errs.Internal, reports[0].Err))
+		assert.Equal(t, http.StatusInternalServerError, reports[0].Status)
+
+		rep.Reset()
+		assert.Empty(t, rep.Reports())
+	})
+}
+
+func TestSampleReporter(t *testing.T) {
+	rep := &errs.MemoryReporter{}
+	for i := 0; i < 10; i++ {
+		errs.SampleReporter(rep, 0).Report(errs.Report{})
+	}
+	assert.Empty(t, rep.Reports())
+
+	for i := 0; i < 10; i++ {
+		errs.SampleReporter(rep, 1).Report(errs.Report{})
+	}
+	assert.Len(t, rep.Reports(), 10)
+}
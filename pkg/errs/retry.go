@@ -0,0 +1,68 @@
+package errs
+
+import (
+	"errors"
+	"time"
+)
+
+// Retry tells whether the failed operation is worth retrying.
+type Retry uint8
+
+const (
+	// RetryUnset derives the retryability from the Kind of the error
+	RetryUnset Retry = iota
+	// Retryable marks the error as worth retrying, regardless of its Kind
+	Retryable
+	// NotRetryable marks the error as not worth retrying, regardless of its Kind
+	NotRetryable
+)
+
+// RetryAfter hints how long to wait before retrying the failed operation.
+type RetryAfter time.Duration
+
+// IsRetryable reports whether the failed operation is worth retrying.
+// The first Retry set along the chain of *Error wins, including the ones wrapped by
+// other errors, otherwise it derives from the Kind of the error, see KindOf, as declared
+// by its KindInfo. An error of no Kind is retryable when it reports itself as temporary,
+// such as a net.Error.
+func IsRetryable(err error) bool {
+	for chain := err; chain != nil; {
+		var e *Error
+		if !errors.As(chain, &e) {
+			break
+		}
+
+		switch e.Retry {
+		case Retryable:
+			return true
+		case NotRetryable:
+			return false
+		}
+		chain = e.Err
+	}
+
+	if kind := KindOf(err); kind != Other {
+		info, _ := LookupKind(kind)
+		return info.Retryable
+	}
+
+	var temporary interface{ Temporary() bool }
+	return errors.As(err, &temporary) && temporary.Temporary()
+}
+
+// RetryAfterOf returns the first RetryAfter hint set along the chain of given error
+func RetryAfterOf(err error) (time.Duration, bool) {
+	for err != nil {
+		var e *Error
+		if !errors.As(err, &e) {
+			break
+		}
+
+		if e.RetryAfter > 0 {
+			return time.Duration(e.RetryAfter), true
+		}
+		err = e.Err
+	}
+
+	return 0, false
+}
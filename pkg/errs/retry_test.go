@@ -0,0 +1,91 @@
+package errs_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type temporaryError struct{ temporary bool }
+
+func (e temporaryError) Error() string   { return "temporary error" }
+func (e temporaryError) Temporary() bool { return e.temporary }
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"derived from IO kind", errs.E(errs.IO, "connection refused"), true},
+		{"derived from Unavailable kind", errs.E(errs.Unavailable, "upstream unavailable"), true},
+		{"derived from NotExist kind", errs.E(errs.NotExist, "not exist"), false},
+		{"explicitly retryable", errs.E(errs.Database, errs.Retryable, "deadlock detected"), true},
+		{"explicitly not retryable", errs.E(errs.IO, errs.NotRetryable, "certificate expired"), false},
+		{"pulled up from inner error", errs.E(errs.Op("user.Create"), errs.E(errs.Database, errs.Retryable, "deadlock detected")), true},
+		{"wrapped", fmt.Errorf("create: %w", errs.E(errs.Timeout, "timed out")), true},
+		{"kind of wrapped inner error", errs.E(errs.Op("user.Create"), fmt.Errorf("query: %w", errs.E(errs.Timeout, "timed out"))), true},
+		{"explicitly retryable behind a wrap", errs.E(errs.Op("user.Create"), errs.NotExist, fmt.Errorf("query: %w", errs.E(errs.Retryable, "replica lagging"))), true},
+		{"explicitly not retryable behind a wrap", errs.E(errs.Op("user.Create"), fmt.Errorf("query: %w", errs.E(errs.IO, errs.NotRetryable, "certificate expired"))), false},
+		{"temporary error wrapped by an operation", errs.E(errs.Op("user.Create"), &net.DNSError{IsTemporary: true}), true},
+		{"temporary error", &net.DNSError{IsTemporary: true}, true},
+		{"not temporary error", temporaryError{temporary: false}, false},
+		{"unknown error", fmt.Errorf("unknown"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errs.IsRetryable(tt.err))
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	err := errs.E(errs.Op("payment.Charge"), errs.E(errs.RateLimited, errs.RetryAfter(1500*time.Millisecond), "too many requests"))
+
+	d, ok := errs.RetryAfterOf(err)
+	assert.True(t, ok)
+	assert.Equal(t, 1500*time.Millisecond, d)
+
+	_, ok = errs.RetryAfterOf(errs.E(errs.RateLimited, "too many requests"))
+	assert.False(t, ok)
+
+	t.Run("header", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		errs.HTTPErrorHandler(w, zerolog.New(os.Stdout), err)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "2", w.Header().Get("Retry-After"))
+
+		w = httptest.NewRecorder()
+		errs.HTTPErrorHandler(w, zerolog.New(os.Stdout), errs.E(errs.RateLimited, "too many requests"))
+		assert.Empty(t, w.Header().Get("Retry-After"))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, jerr := json.Marshal(err)
+		require.NoError(t, jerr)
+		assert.Contains(t, string(data), `"retry_after":"1.5s"`)
+
+		var got errs.Error
+		require.NoError(t, json.Unmarshal(data, &got))
+		d, ok := errs.RetryAfterOf(&got)
+		assert.True(t, ok)
+		assert.Equal(t, 1500*time.Millisecond, d)
+
+		data, jerr = json.Marshal(errs.E(errs.IO, errs.NotRetryable, "certificate expired"))
+		require.NoError(t, jerr)
+		require.NoError(t, json.Unmarshal(data, &got))
+		assert.False(t, errs.IsRetryable(&got))
+	})
+}
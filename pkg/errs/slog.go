@@ -0,0 +1,182 @@
+//go:build go1.21
+
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// LogValue implements slog.LogValuer, logging the error as a group of its
+// message, operations, kind, code, parameter, user, fields and stack trace.
+// It is redacted by DefaultRedactor.
+func (e *Error) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("message", DefaultRedactor.Message(e.Error())),
+		slog.String("kind", e.Kind.String()),
+	}
+
+	if ops := Ops(e); len(ops) > 0 {
+		attrs = append(attrs, slog.Any("ops", opStrings(ops)))
+	}
+	if e.Code != "" {
+		attrs = append(attrs, slog.String("code", string(e.Code)))
+	}
+	if e.Param != "" {
+		attrs = append(attrs, slog.String("param", string(e.Param)))
+	}
+	if e.User != "" {
+		attrs = append(attrs, slog.String("user", DefaultRedactor.UserName(e.User)))
+	}
+	if f := DefaultRedactor.Fields(e.Fields); len(f) > 0 {
+		keys := make([]string, 0, len(f))
+		for k := range f {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		fields := make([]interface{}, 0, len(keys))
+		for _, k := range keys {
+			fields = append(fields, slog.Any(k, f[k]))
+		}
+		attrs = append(attrs, slog.Group("fields", fields...))
+	}
+	if st := MarshalStack(e); st != nil {
+		attrs = append(attrs, slog.Any("stack", st))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer, masking the sensitive value
+func (s sensitive) LogValue() slog.Value {
+	return slog.StringValue(RedactedMask)
+}
+
+// SlogLogHook is the slog counterpart of LogHook, see WithSlogLogHook
+type SlogLogHook func(lgr *slog.Logger, status int, err error)
+
+// WithSlogLogHook replaces the slog counterpart of the default LogHook, which logs the error
+// handled by HandleSlog. Passing nil disables logging.
+func WithSlogLogHook(fn SlogLogHook) HandlerOption {
+	return func(h *HTTPHandler) {
+		h.slogLogHook = fn
+	}
+}
+
+// SlogHTTPErrorHandler is the same as HTTPRequestErrorHandler, logging through given slog.Logger
+func SlogHTTPErrorHandler(w http.ResponseWriter, r *http.Request, lgr *slog.Logger, err error, opts ...HandlerOption) {
+	NewHTTPHandler(opts...).HandleSlog(w, r, lgr, err)
+}
+
+// HandleSlog is the same as Handle, logging through given slog.Logger.
+// The error is logged by the slog counterpart of the default LogHook,
+// unless replaced through WithSlogLogHook, the LogHook set by WithLogHook is not used.
+func (h *HTTPHandler) HandleSlog(w http.ResponseWriter, r *http.Request, lgr *slog.Logger, err error) {
+	h.handle(w, r, h.slogLog(lgr), err, false)
+}
+
+// slogLog returns the handlerLog logging through given slog.Logger
+func (h *HTTPHandler) slogLog(lgr *slog.Logger) handlerLog {
+	lg := handlerLog{
+		failure: func(msg string, err error) {
+			lgr.LogAttrs(context.Background(), slog.LevelError, msg, slogErr(err))
+		},
+		suppressed: func(fingerprint string, n int, since time.Time) {
+			lgr.LogAttrs(context.Background(), slog.LevelWarn, fmt.Sprintf("suppressed %d similar errors", n),
+				slog.String("fingerprint", fingerprint),
+				slog.Int("suppressed", n),
+				slog.Time("since", since))
+		},
+	}
+
+	hook, ok := h.slogLogHook.(SlogLogHook)
+	if !ok {
+		hook = h.defaultSlogLogHook
+	}
+	if hook != nil {
+		lg.hook = func(status int, err error) { hook(lgr, status, err) }
+	}
+
+	return lg
+}
+
+// defaultSlogLogHook is the slog counterpart of defaultLogHook
+func (h *HTTPHandler) defaultSlogLogHook(lgr *slog.Logger, status int, err error) {
+	if err == nil {
+		lgr.Error("nil error - no response body sent", slog.Int("status", status))
+		return
+	}
+
+	if m, kind, ok := multiErrorOf(err); ok {
+		logSlog(lgr, h.logLevel(kind), "multiple errors",
+			slog.Int("status", status),
+			slog.String("error", redacted(err).Error()),
+			slog.String("kind", kind.String()),
+			slog.Int("errors", len(m)))
+		return
+	}
+
+	var e *Error
+	if !errors.As(err, &e) {
+		lgr.Error("unknown error", slog.Int("status", status), slog.String("error", redacted(err).Error()))
+		return
+	}
+
+	kind := KindOf(err)
+	msg := "common error"
+	switch kind {
+	case Validation:
+		msg = "input validation error"
+	case Unauthenticated:
+		msg = "unauthenticated request"
+	case Unauthorized:
+		msg = "unauthorized request"
+	}
+
+	logSlog(lgr, h.logLevel(kind), msg, slog.Int("status", status), slog.Any("error", e))
+}
+
+// logSlog logs at the slog counterpart of given zerolog level
+func logSlog(lgr *slog.Logger, level zerolog.Level, msg string, attrs ...slog.Attr) {
+	lvl, ok := slogLevel(level)
+	if !ok {
+		return
+	}
+
+	lgr.LogAttrs(context.Background(), lvl, msg, attrs...)
+}
+
+// slogLevel translates a zerolog level into slog, it returns false for levels not logged
+func slogLevel(level zerolog.Level) (slog.Level, bool) {
+	switch level {
+	case zerolog.TraceLevel:
+		return slog.LevelDebug - 4, true
+	case zerolog.DebugLevel:
+		return slog.LevelDebug, true
+	case zerolog.InfoLevel, zerolog.NoLevel:
+		return slog.LevelInfo, true
+	case zerolog.WarnLevel:
+		return slog.LevelWarn, true
+	case zerolog.ErrorLevel, zerolog.FatalLevel, zerolog.PanicLevel:
+		return slog.LevelError, true
+	default:
+		return 0, false
+	}
+}
+
+// slogErr returns the attribute of given error, an *Error is logged as a group, see LogValue
+func slogErr(err error) slog.Attr {
+	if e, ok := err.(*Error); ok {
+		return slog.Any("error", e)
+	}
+
+	return slog.String("error", err.Error())
+}
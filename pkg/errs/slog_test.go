@@ -0,0 +1,121 @@
+//go:build go1.21
+
+package errs_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	lgr := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	err := errs.E(errs.Op("user.Create"), errs.UserName("john"), errs.Field("tenant", "acme"),
+		errs.E(errs.Op("db.Insert"), errs.Database, errs.Code("duplicate_user"), errs.Parameter("email"), "duplicate key"))
+	lgr.Error("failed", slog.Any("error", err))
+
+	var got struct {
+		Error map[string]interface{} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+
+	assert.Equal(t, "user.Create: db.Insert: duplicate key", got.Error["message"])
+	assert.Equal(t, "database_error", got.Error["kind"])
+	assert.Equal(t, []interface{}{"user.Create", "db.Insert"}, got.Error["ops"])
+	assert.Equal(t, "duplicate_user", got.Error["code"])
+	assert.Equal(t, "email", got.Error["param"])
+	assert.Equal(t, "john", got.Error["user"])
+	assert.Equal(t, map[string]interface{}{"tenant": "acme"}, got.Error["fields"])
+	assert.NotEmpty(t, got.Error["stack"])
+}
+
+func TestSlogHTTPErrorHandler(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := httptest.NewRecorder()
+		errs.SlogHTTPErrorHandler(w, httptest.NewRequest(http.MethodGet, "/", nil), slog.New(slog.NewJSONHandler(&buf, nil)), errs.E(errs.NotExist, errs.Code("product_not_exist"), "not exist"))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, `{"error":{"kind":"resource_does_not_exist","code":"product_not_exist","message":"not exist"}}`, w.Body.String())
+		assert.Contains(t, buf.String(), `"level":"ERROR","msg":"common error","status":404,"error":{"message":"not exist","kind":"resource_does_not_exist","code":"product_not_exist"`)
+	})
+
+	t.Run("negotiated", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept", errs.MIMEApplicationProblemJSON)
+
+		w := httptest.NewRecorder()
+		errs.SlogHTTPErrorHandler(w, r, slog.New(slog.NewJSONHandler(io.Discard, nil)), errs.E(errs.NotExist, "not exist"), errs.WithRenderers(errs.ProblemRenderer{}))
+		assert.Equal(t, errs.MIMEApplicationProblemJSON, w.Header().Get("Content-Type"))
+	})
+
+	t.Run("log levels", func(t *testing.T) {
+		var buf bytes.Buffer
+		h := errs.NewHTTPHandler(errs.WithLogLevels(map[errs.Kind]zerolog.Level{errs.NotExist: zerolog.InfoLevel}))
+		h.HandleSlog(httptest.NewRecorder(), nil, slog.New(slog.NewJSONHandler(&buf, nil)), errs.E(errs.NotExist, "not exist"))
+
+		assert.Contains(t, buf.String(), `"level":"INFO"`)
+	})
+
+	t.Run("sampling summary", func(t *testing.T) {
+		var buf bytes.Buffer
+		lgr := slog.New(slog.NewJSONHandler(&buf, nil))
+		s, c := newTestLogSampler(1, time.Minute)
+		h := errs.NewHTTPHandler(errs.WithLogSampling(s))
+
+		newErr := func() error { return errs.E(errs.Database, "duplicate key") }
+		h.HandleSlog(httptest.NewRecorder(), nil, lgr, newErr())
+		h.HandleSlog(httptest.NewRecorder(), nil, lgr, newErr())
+		c.now = c.now.Add(time.Minute)
+		buf.Reset()
+		h.HandleSlog(httptest.NewRecorder(), nil, lgr, newErr())
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], `"level":"WARN","msg":"suppressed 1 similar errors"`)
+	})
+	t.Run("custom log hook", func(t *testing.T) {
+		var got []int
+		h := errs.NewHTTPHandler(errs.WithSlogLogHook(func(lgr *slog.Logger, status int, err error) {
+			got = append(got, status)
+		}))
+		h.HandleSlog(httptest.NewRecorder(), nil, slog.Default(), errs.E(errs.NotExist, "not exist"))
+		assert.Equal(t, []int{http.StatusNotFound}, got)
+
+		var buf bytes.Buffer
+		errs.NewHTTPHandler(errs.WithSlogLogHook(nil)).HandleSlog(httptest.NewRecorder(), nil, slog.New(slog.NewJSONHandler(&buf, nil)), errs.E(errs.NotExist, "not exist"))
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("render failure", func(t *testing.T) {
+		var buf bytes.Buffer
+		h := errs.NewHTTPHandler(errs.WithRenderer(failingRenderer{}), errs.WithSlogLogHook(nil))
+
+		w := httptest.NewRecorder()
+		h.HandleSlog(w, nil, slog.New(slog.NewJSONHandler(&buf, nil)), errs.E(errs.NotExist, "not exist"))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, buf.String(), `"level":"ERROR","msg":"unable to render error response","error":"cannot render"`)
+	})
+}
+
+type failingRenderer struct{}
+
+func (failingRenderer) ContentType() string { return "application/json" }
+
+func (failingRenderer) Render(int, errs.Kind, errs.HTTPErrResponse) ([]byte, error) {
+	return nil, errors.New("cannot render")
+}
@@ -0,0 +1,92 @@
+package errs
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+)
+
+// StackDepth is the maximum number of frames captured by E.
+// Set it to 0 to disable the stack capture altogether.
+var StackDepth = 32
+
+// NoStack disables the stack capture of the error built by E, such as in hot paths:
+//
+//	errs.E(errs.NotExist, errs.NoStack, "cache miss")
+var NoStack = noStack{}
+
+type noStack struct{}
+
+// stack is the program counters of a call stack
+type stack []uintptr
+
+// callers captures the call stack, skipping the given number of frames
+// as in runtime.Callers
+func callers(skip int) stack {
+	if StackDepth <= 0 {
+		return nil
+	}
+
+	pcs := make([]uintptr, StackDepth)
+	n := runtime.Callers(skip, pcs)
+	return pcs[:n]
+}
+
+func (s stack) frames() []runtime.Frame {
+	if len(s) == 0 {
+		return nil
+	}
+
+	var out []runtime.Frame
+	frames := runtime.CallersFrames(s)
+	for {
+		fr, more := frames.Next()
+		out = append(out, fr)
+		if !more {
+			break
+		}
+	}
+
+	return out
+}
+
+// StackTrace returns the call stack captured by the innermost *Error
+// of the error chain, or nil if there is none.
+func StackTrace(err error) []runtime.Frame {
+	var st stack
+	for err != nil {
+		var e *Error
+		if !errors.As(err, &e) {
+			break
+		}
+
+		if len(e.stack) > 0 {
+			st = e.stack
+		}
+		err = e.Err
+	}
+
+	return st.frames()
+}
+
+// MarshalStack returns the stack trace of given error in the shape expected
+// by zerolog, it is meant to be set as the zerolog.ErrorStackMarshaler:
+//
+//	zerolog.ErrorStackMarshaler = errs.MarshalStack
+func MarshalStack(err error) interface{} {
+	frames := StackTrace(err)
+	if len(frames) == 0 {
+		return nil
+	}
+
+	out := make([]map[string]string, 0, len(frames))
+	for _, fr := range frames {
+		out = append(out, map[string]string{
+			"func":   fr.Function,
+			"source": fr.File,
+			"line":   strconv.Itoa(fr.Line),
+		})
+	}
+
+	return out
+}
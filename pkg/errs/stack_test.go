@@ -0,0 +1,69 @@
+package errs_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStackTrace(t *testing.T) {
+	err := errs.E(errs.Database, "duplicate key")
+
+	t.Run("captured at creation", func(t *testing.T) {
+		frames := errs.StackTrace(err)
+		require.NotEmpty(t, frames)
+		assert.True(t, strings.HasSuffix(frames[0].Function, "errs_test.TestStackTrace"), frames[0].Function)
+	})
+
+	t.Run("innermost stack is kept", func(t *testing.T) {
+		frames := errs.StackTrace(fmt.Errorf("wrapped: %w", errs.E(errs.Op("user.Create"), err)))
+		require.NotEmpty(t, frames)
+		assert.True(t, strings.HasSuffix(frames[0].Function, "errs_test.TestStackTrace"), frames[0].Function)
+	})
+
+	t.Run("no stack", func(t *testing.T) {
+		assert.Empty(t, errs.StackTrace(errs.E(errs.NotExist, errs.NoStack, "cache miss")))
+		assert.Empty(t, errs.StackTrace(fmt.Errorf("plain error")))
+		assert.Empty(t, errs.StackTrace(nil))
+	})
+
+	t.Run("depth", func(t *testing.T) {
+		defer func(d int) { errs.StackDepth = d }(errs.StackDepth)
+
+		errs.StackDepth = 1
+		assert.Len(t, errs.StackTrace(errs.E(errs.Database, "duplicate key")), 1)
+
+		errs.StackDepth = 0
+		assert.Empty(t, errs.StackTrace(errs.E(errs.Database, "duplicate key")))
+	})
+}
+
+func TestFormatStack(t *testing.T) {
+	err := errs.E(errs.Database, "duplicate key")
+
+	assert.Equal(t, "duplicate key", fmt.Sprintf("%v", err))
+	assert.Equal(t, "duplicate key", fmt.Sprintf("%s", err))
+
+	out := fmt.Sprintf("%+v", err)
+	assert.True(t, strings.HasPrefix(out, "duplicate key\n"), out)
+	assert.Contains(t, out, "errs_test.TestFormatStack\n\t")
+	assert.Contains(t, out, "stack_test.go:")
+}
+
+func TestMarshalStack(t *testing.T) {
+	defer func(m func(err error) interface{}) { zerolog.ErrorStackMarshaler = m }(zerolog.ErrorStackMarshaler)
+	zerolog.ErrorStackMarshaler = errs.MarshalStack
+
+	var buff bytes.Buffer
+	l := zerolog.New(&buff)
+	l.Error().Stack().Err(errs.E(errs.Database, "duplicate key")).Msg("failed")
+
+	assert.Contains(t, buff.String(), `"stack":[{"func":"github.com/ardikabs/golib/pkg/errs_test.TestMarshalStack","line":"`)
+	assert.Nil(t, errs.MarshalStack(fmt.Errorf("plain error")))
+}
@@ -0,0 +1,55 @@
+package errs
+
+import (
+	"github.com/rs/zerolog"
+)
+
+// MarshalZerologObject implements zerolog.LogObjectMarshaler, logging the error as an object of
+// its message, kind, operations, code, parameter, user, realm, fields and validation errors,
+// such as through zerolog.Event.Err or zerolog.Event.Object. It is redacted by DefaultRedactor.
+func (e *Error) MarshalZerologObject(ev *zerolog.Event) {
+	ev.Str("message", DefaultRedactor.Message(e.Error())).
+		Str("kind", e.Kind.String())
+
+	if ops := Ops(e); len(ops) > 0 {
+		ev.Strs("ops", opStrings(ops))
+	}
+	if e.Code != "" {
+		ev.Str("code", string(e.Code))
+	}
+	if e.Param != "" {
+		ev.Str("param", string(e.Param))
+	}
+	if e.User != "" {
+		ev.Str("user", DefaultRedactor.UserName(e.User))
+	}
+	if e.Realm != "" {
+		ev.Str("realm", string(e.Realm))
+	}
+	if len(e.Fields) > 0 {
+		ev.Dict("fields", zerolog.Dict().Fields(map[string]interface{}(DefaultRedactor.Fields(e.Fields))))
+	}
+	if verr, ok := e.Err.(ValidationErrors); ok {
+		ev.Array("errors", verr.zerologArray())
+	}
+}
+
+// MarshalZerologObject implements zerolog.LogObjectMarshaler, logging the number
+// of validation errors along with the errors themselves
+func (v ValidationErrors) MarshalZerologObject(ev *zerolog.Event) {
+	ev.Int("count", len(v)).
+		Array("errors", v.zerologArray())
+}
+
+func (v ValidationErrors) zerologArray() *zerolog.Array {
+	arr := zerolog.Arr()
+	for _, err := range v {
+		if e, ok := err.(*Error); ok {
+			arr.Object(e)
+			continue
+		}
+		arr.Str(DefaultRedactor.Message(err.Error()))
+	}
+
+	return arr
+}
@@ -0,0 +1,62 @@
+package errs_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/ardikabs/golib/pkg/errs"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_MarshalZerologObject(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "all fields",
+			err: errs.E(errs.Op("user.Create"), errs.UserName("john"), errs.Field("tenant", "acme"),
+				errs.E(errs.Op("db.Insert"), errs.Database, errs.Code("duplicate_user"), errs.Parameter("email"), "duplicate key")),
+			want: `{"error":{"message":"user.Create: db.Insert: duplicate key","kind":"database_error","ops":["user.Create","db.Insert"],"code":"duplicate_user","param":"email","user":"john","fields":{"tenant":"acme"}}}`,
+		},
+		{
+			name: "realm",
+			err:  errs.E(errs.Unauthenticated, "token expired"),
+			want: `{"error":{"message":"token expired","kind":"unauthenticated_request","realm":"restricted"}}`,
+		},
+		{
+			name: "validation errors",
+			err: errs.E(errs.Validation, errs.ValidationErrors{
+				errs.E(errs.Parameter("email"), errs.NoStack, "email is required"),
+				fmt.Errorf("malformed body"),
+			}),
+			want: `{"error":{"message":"email: email is required","kind":"input_validation_error","errors":[{"message":"email is required","kind":"other_error","param":"email"},"malformed body"]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := zerolog.New(&buf)
+			l.Log().Err(tt.err).Send()
+
+			assert.JSONEq(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestValidationErrors_MarshalZerologObject(t *testing.T) {
+	verr := errs.ValidationErrors{
+		errs.E(errs.Parameter("email"), "email is required"),
+		errs.E(errs.Parameter("name"), "name is required"),
+	}
+
+	var buf bytes.Buffer
+	l := zerolog.New(&buf)
+	l.Log().Object("validation", verr).Send()
+
+	assert.JSONEq(t, `{"validation":{"count":2,"errors":[{"message":"email is required","kind":"other_error","param":"email"},{"message":"name is required","kind":"other_error","param":"name"}]}}`, buf.String())
+}
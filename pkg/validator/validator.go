@@ -5,14 +5,32 @@ import (
 )
 
 type Validator struct {
-	stash map[errs.Parameter]bool
-	err   errs.ValidationErrors
+	stash     map[errs.Parameter]bool
+	err       errs.ValidationErrors
+	allErrors bool
 }
 
-func New() *Validator {
-	return &Validator{
+// Option configures the Validator
+type Option func(*Validator)
+
+// WithAllErrors keeps every error added for a parameter,
+// instead of only the first one
+func WithAllErrors() Option {
+	return func(v *Validator) {
+		v.allErrors = true
+	}
+}
+
+func New(opts ...Option) *Validator {
+	v := &Validator{
 		stash: make(map[errs.Parameter]bool),
 	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
 }
 
 func (v *Validator) Valid() error {
@@ -42,7 +60,7 @@ func (v *Validator) AddError(param errs.Parameter, args ...interface{}) {
 		panic("validator.AddError: must be contains one of the followings `errs.Code`, `string`, or `error`")
 	}
 
-	if _, exist := v.stash[param]; !exist {
+	if _, exist := v.stash[param]; !exist || v.allErrors {
 		v.stash[param] = true
 		v.err.Append(param, errs.E(args...))
 	}
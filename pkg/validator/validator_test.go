@@ -18,6 +18,30 @@ func TestValidator(t *testing.T) {
 	assert.NotNil(t, v.Valid())
 }
 
+func TestValidatorAllErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []validator.Option
+		want int
+	}{
+		{name: "first error per parameter", want: 2},
+		{name: "all errors", opts: []validator.Option{validator.WithAllErrors()}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validator.New(tt.opts...)
+			v.Check(false, errs.Parameter("email"), errs.Code("required"), "email is required")
+			v.Check(false, errs.Parameter("email"), errs.Code("bad_format"), "email is malformed")
+			v.Check(false, errs.Path("items", 2, "sku"), errs.Code("required"), "sku is required")
+
+			err, ok := v.Valid().(*errs.Error)
+			assert.True(t, ok)
+			assert.Len(t, err.Err, tt.want)
+		})
+	}
+}
+
 func TestValidatorPanic(t *testing.T) {
 	v := validator.New()
 